@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal"
+)
+
+func main() {
+	endpoint := os.Getenv("TERMINAL_API_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "https://api.terminal.shop"
+	}
+	useDev, _ := strconv.ParseBool(os.Getenv("TERMINAL_USE_DEV"))
+
+	flag.StringVar(&endpoint, "endpoint", endpoint, "Terminal API endpoint, defaults to TERMINAL_API_ENDPOINT")
+	flag.BoolVar(&useDev, "dev", useDev, "use the Terminal development environment, defaults to TERMINAL_USE_DEV")
+	dir := flag.String("dir", ".", "directory to write the generated .tf files to")
+	ordersSince := flag.Duration("orders-since", 30*24*time.Hour, "include orders placed within this duration, 0 leaves orders out")
+	force := flag.Bool("force", false, "overwrite existing files")
+	flag.Parse()
+
+	// The token is only read from the environment, so it stays out of shell
+	// history
+	token := os.Getenv("TERMINAL_API_TOKEN")
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "Error: set TERMINAL_API_TOKEN to the API token of the account to import")
+		os.Exit(1)
+	}
+	if useDev {
+		endpoint = "https://api.dev.terminal.shop"
+	}
+
+	client, err := terminal.NewClient(endpoint, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+
+	var opts terminal.ImportOptions
+	if *ordersSince > 0 {
+		opts.OrdersSince = time.Now().Add(-*ordersSince)
+	}
+
+	config, err := terminal.GenerateImportConfig(context.Background(), client, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading account: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, warning := range config.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if len(config.Files) == 0 {
+		fmt.Println("Nothing to import")
+		return
+	}
+
+	// Check every file first, so nothing is written when one would be
+	// overwritten
+	names := slices.Sorted(maps.Keys(config.Files))
+	if !*force {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(*dir, name)); err == nil {
+				fmt.Fprintf(os.Stderr, "Error: %s already exists, use -force to overwrite it\n", filepath.Join(*dir, name))
+				os.Exit(1)
+			}
+		}
+	}
+
+	for _, name := range names {
+		path := filepath.Join(*dir, name)
+		if err := os.WriteFile(path, config.Files[name], 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+
+	// Print instructions
+	fmt.Println("\nReview the generated files, then run terraform plan to see what will be imported.")
+	fmt.Println("Once applied, the import blocks can be deleted.")
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal/mockapi"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8787", "address to listen on")
+	token := flag.String("token", mockapi.DefaultToken, "bearer token accepted by the mock API")
+	flag.Parse()
+
+	api := mockapi.New()
+	api.SetToken(*token)
+
+	// Print instructions
+	fmt.Printf("Mock Terminal API listening on http://%s\n", *addr)
+	fmt.Println("Point the provider at it with:")
+	fmt.Println("  provider \"terminal-coffee\" {")
+	fmt.Printf("    api_endpoint = \"http://%s\"\n", *addr)
+	fmt.Printf("    api_token    = \"%s\"\n", *token)
+	fmt.Println("  }")
+
+	log.Fatal(http.ListenAndServe(*addr, api))
+}
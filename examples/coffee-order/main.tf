@@ -11,13 +11,20 @@ provider "terminal-coffee" {
   api_token = var.api_token
 }
 
+# Look up the coffee to order by name instead of hardcoding variant IDs
+data "terminal_product" "segfault" {
+  name = "segfault"
+}
+
 # Order a coffee
 resource "terminal_coffee_order" "coffee" {
   address_id = var.address_id
   card_id    = var.card_id
-  
-  variants = {
-    "var_1234567890" = "1"  # One of variant var_1234567890
+
+  # One 12oz bag of segfault
+  item {
+    variant_id = data.terminal_product.segfault.variant_ids["12oz"]
+    quantity   = 1
   }
 }
 
@@ -44,5 +51,6 @@ output "existing_order" {
     total      = data.terminal_coffee_order.existing_order.total
     created_at = data.terminal_coffee_order.existing_order.created_at
     items      = data.terminal_coffee_order.existing_order.items
+    tracking   = data.terminal_coffee_order.existing_order.tracking
   }
 }
\ No newline at end of file
@@ -1,16 +1,30 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"flag"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal"
 )
 
+// version is set at release time with -ldflags "-X main.version=..."
+var version = "dev"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return terminal.Provider()
-		},
+	var debug bool
+
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
+
+	err := providerserver.Serve(context.Background(), terminal.New(version), providerserver.ServeOpts{
+		Address:         "registry.terraform.io/OZCAP/terminal-coffee",
+		Debug:           debug,
+		ProtocolVersion: 6,
 	})
-}
\ No newline at end of file
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
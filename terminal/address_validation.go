@@ -0,0 +1,186 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// isoCountryCodes are the ISO 3166-1 alpha-2 country codes
+var isoCountryCodes = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, code := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ
+		BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+		CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ
+		DE DJ DK DM DO DZ
+		EC EE EG EH ER ES ET
+		FI FJ FK FM FO FR
+		GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY
+		HK HM HN HR HT HU
+		ID IE IL IM IN IO IQ IR IS IT
+		JE JM JO JP
+		KE KG KH KI KM KN KP KR KW KY KZ
+		LA LB LC LI LK LR LS LT LU LV LY
+		MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ
+		NA NC NE NF NG NI NL NO NP NR NU NZ
+		OM
+		PA PE PF PG PH PK PL PM PN PR PS PT PW PY
+		QA
+		RE RO RS RU RW
+		SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ
+		TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ
+		UA UG UM US UY UZ
+		VA VC VE VG VI VN VU
+		WF WS
+		YE YT
+		ZA ZM ZW
+	`) {
+		codes[code] = true
+	}
+	return codes
+}()
+
+// postalCodePatterns are the formats of normalized postal codes by country.
+// Countries without a pattern accept any postal code.
+var postalCodePatterns = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^\d{4}$`),
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+	"CA": regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d$`),
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"FI": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"IE": regexp.MustCompile(`^(?:[AC-FHKNPRTV-Y]\d{2}|D6W) ?[\dAC-FHKNPRTV-Y]{4}$`),
+	"IN": regexp.MustCompile(`^\d{6}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"MX": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"NO": regexp.MustCompile(`^\d{4}$`),
+	"NZ": regexp.MustCompile(`^\d{4}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+}
+
+// postalCodeExamples are shown when a postal code doesn't match its pattern
+var postalCodeExamples = map[string]string{
+	"BR": "01310-100",
+	"CA": "K1A 0B1",
+	"GB": "SW1A 1AA",
+	"IE": "D02 X285",
+	"JP": "100-0001",
+	"NL": "1012 JS",
+	"PL": "00-950",
+	"PT": "1000-001",
+	"SE": "114 55",
+	"US": "94103 or 94103-1234",
+}
+
+// stateRequiredCountries are the countries whose addresses need a state or
+// province
+var stateRequiredCountries = map[string]bool{
+	"AU": true,
+	"BR": true,
+	"CA": true,
+	"IN": true,
+	"MX": true,
+	"US": true,
+}
+
+// e164Pattern matches a phone number in E.164 format, such as +14155550123
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// phoneSeparators are removed when normalizing a phone number
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// normalizePhoneNumber removes the spaces, dashes, dots and parentheses that
+// commonly separate the digits of a phone number, so "+1 (415) 555-0123"
+// becomes "+14155550123"
+func normalizePhoneNumber(s string) string {
+	return phoneSeparators.Replace(strings.TrimSpace(s))
+}
+
+// normalizeAddressText trims an address field and collapses runs of
+// whitespace
+func normalizeAddressText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// normalizeAddressCode normalizes a country or postal code, which are
+// compared ignoring case
+func normalizeAddressCode(s string) string {
+	return strings.ToUpper(normalizeAddressText(s))
+}
+
+// addressProblem is a part of an address that doesn't suit its country
+type addressProblem struct {
+	Attribute string
+	Summary   string
+	Detail    string
+}
+
+// validateAddress checks that the normalized postal code and state suit the
+// country. Unknown values are skipped.
+func validateAddress(country, zip, state types.String) []addressProblem {
+	if country.IsNull() || country.IsUnknown() {
+		return nil
+	}
+
+	code := normalizeAddressCode(country.ValueString())
+	var problems []addressProblem
+
+	if pattern, ok := postalCodePatterns[code]; ok && !zip.IsNull() && !zip.IsUnknown() {
+		if !pattern.MatchString(normalizeAddressCode(zip.ValueString())) {
+			detail := fmt.Sprintf("%q is not a valid postal code for %s", zip.ValueString(), code)
+			if example, ok := postalCodeExamples[code]; ok {
+				detail += fmt.Sprintf(", expected a code such as %s", example)
+			}
+			problems = append(problems, addressProblem{"zip", "Invalid postal code", detail})
+		}
+	}
+
+	if stateRequiredCountries[code] && !state.IsUnknown() && normalizeAddressText(state.ValueString()) == "" {
+		problems = append(problems, addressProblem{"state", "Missing state", fmt.Sprintf("Addresses in %s need a state or province", code)})
+	}
+
+	return problems
+}
+
+// normalizedString keeps the prior value of an attribute when the API
+// returns it normalized, so refreshing doesn't report cosmetic differences
+func normalizedString(prior types.String, remote string, normalize func(string) string) types.String {
+	if !prior.IsNull() && normalize(prior.ValueString()) == normalize(remote) {
+		return prior
+	}
+
+	return optionalString(remote)
+}
+
+// requiresReplaceUnlessNormalized forces a new address when an attribute
+// changes beyond the differences removed by normalize. Cosmetic changes are
+// stored in place.
+func requiresReplaceUnlessNormalized(normalize func(string) string) []planmodifier.String {
+	return []planmodifier.String{
+		stringplanmodifier.RequiresReplaceIf(
+			func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+				resp.RequiresReplace = req.StateValue.IsNull() || req.PlanValue.IsNull() ||
+					normalize(req.StateValue.ValueString()) != normalize(req.PlanValue.ValueString())
+			},
+			"Changes other than case and whitespace require replacement",
+			"Changes other than case and whitespace require replacement",
+		),
+	}
+}
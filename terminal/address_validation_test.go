@@ -0,0 +1,92 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		country    types.String
+		zip        types.String
+		state      types.String
+		attributes []string
+	}{
+		{"us zip", types.StringValue("US"), types.StringValue("94103"), types.StringValue("CA"), nil},
+		{"us zip+4", types.StringValue("us"), types.StringValue(" 94103-1234 "), types.StringValue("CA"), nil},
+		{"us short zip", types.StringValue("US"), types.StringValue("9410"), types.StringValue("CA"), []string{"zip"}},
+		{"us missing state", types.StringValue("US"), types.StringValue("94103"), types.StringNull(), []string{"state"}},
+		{"us blank state", types.StringValue("US"), types.StringValue("94103"), types.StringValue("  "), []string{"state"}},
+		{"uk postcode", types.StringValue("GB"), types.StringValue("sw1a 1aa"), types.StringNull(), nil},
+		{"uk bad postcode", types.StringValue("GB"), types.StringValue("12345"), types.StringNull(), []string{"zip"}},
+		{"ca postal code", types.StringValue("CA"), types.StringValue("K1A0B1"), types.StringValue("ON"), nil},
+		{"ca bad postal code and state", types.StringValue("CA"), types.StringValue("12345"), types.StringNull(), []string{"zip", "state"}},
+		{"de postcode", types.StringValue("DE"), types.StringValue("10115"), types.StringNull(), nil},
+		{"de bad postcode", types.StringValue("DE"), types.StringValue("1011"), types.StringNull(), []string{"zip"}},
+		{"ie eircode", types.StringValue("IE"), types.StringValue("D6W XY12"), types.StringNull(), nil},
+		{"no pattern", types.StringValue("KE"), types.StringValue("anything"), types.StringNull(), nil},
+		{"unknown country", types.StringUnknown(), types.StringValue("1"), types.StringNull(), nil},
+		{"unknown zip and state", types.StringValue("US"), types.StringUnknown(), types.StringUnknown(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attributes []string
+			for _, problem := range validateAddress(tt.country, tt.zip, tt.state) {
+				attributes = append(attributes, problem.Attribute)
+			}
+			if len(attributes) != len(tt.attributes) {
+				t.Fatalf("expected problems with %v, got %v", tt.attributes, attributes)
+			}
+			for i := range attributes {
+				if attributes[i] != tt.attributes[i] {
+					t.Fatalf("expected problems with %v, got %v", tt.attributes, attributes)
+				}
+			}
+		})
+	}
+}
+
+func TestIsoCountryCodes(t *testing.T) {
+	if len(isoCountryCodes) != 249 {
+		t.Errorf("expected 249 country codes, got %d", len(isoCountryCodes))
+	}
+	for code := range postalCodePatterns {
+		if !isoCountryCodes[code] {
+			t.Errorf("postal code pattern for unknown country %s", code)
+		}
+	}
+}
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := map[string]bool{
+		"+14155550123":       true,
+		"+1 (415) 555-0123":  true,
+		" +44 20 7946 0958 ": true,
+		"+49.30.901820":      true,
+		"4155550123":         false,
+		"+04155550123":       false,
+		"+1415555012345678":  false,
+		"+1 415 CALL-NOW":    false,
+	}
+
+	for phone, valid := range tests {
+		if got := e164Pattern.MatchString(normalizePhoneNumber(phone)); got != valid {
+			t.Errorf("%q: expected valid %t, got %t", phone, valid, got)
+		}
+	}
+}
+
+func TestNormalizedString(t *testing.T) {
+	if got := normalizedString(types.StringValue(" ca "), "CA", normalizeAddressCode); got.ValueString() != " ca " {
+		t.Errorf("expected the prior value to be kept, got %q", got.ValueString())
+	}
+	if got := normalizedString(types.StringValue("CA"), "NY", normalizeAddressCode); got.ValueString() != "NY" {
+		t.Errorf("expected the remote value, got %q", got.ValueString())
+	}
+	if got := normalizedString(types.StringNull(), "", normalizeAddressText); !got.IsNull() {
+		t.Errorf("expected null, got %q", got.ValueString())
+	}
+}
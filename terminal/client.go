@@ -2,9 +2,12 @@ package terminal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/terminaldotshop/terminal-sdk-go"
 	"github.com/terminaldotshop/terminal-sdk-go/option"
@@ -13,6 +16,12 @@ import (
 // SDKClient wraps the Terminal SDK client for use in Terraform
 type SDKClient struct {
 	Client *terminal.Client
+	// Endpoint is the base URL the client sends requests to
+	Endpoint string
+	// Limits are the spending guardrails checked before placing an order
+	Limits orderLimits
+	// Retry controls how failed requests are retried
+	Retry retryPolicy
 }
 
 // NewClient creates a new Terminal SDK client
@@ -34,12 +43,22 @@ func NewClient(apiEndpoint, apiToken string) (*SDKClient, error) {
 		opts = append(opts, option.WithBaseURL(apiEndpoint))
 	}
 
+	c := &SDKClient{
+		Endpoint: apiEndpoint,
+		Retry:    defaultRetryPolicy,
+	}
+
+	// Retries are handled by our own middleware, which knows which requests
+	// are safe to repeat, rather than the SDK's
+	opts = append(opts,
+		option.WithMaxRetries(0),
+		option.WithMiddleware(c.retryMiddleware),
+	)
+
 	// Create the SDK client
-	client := terminal.NewClient(opts...)
+	c.Client = terminal.NewClient(opts...)
 
-	return &SDKClient{
-		Client: client,
-	}, nil
+	return c, nil
 }
 
 // CreateAddress creates a new shipping address
@@ -60,11 +79,14 @@ func (c *SDKClient) CreateAddress(ctx context.Context, address *Address) (*Addre
 	if address.State != "" {
 		params.Province = terminal.String(address.State)
 	}
+	if address.Phone != "" {
+		params.Phone = terminal.String(address.Phone)
+	}
 
 	// Make the API call
 	response, err := c.Client.Address.New(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("error creating address: %v", err)
+		return nil, wrapError("error creating address", err)
 	}
 
 	// Create a new address with the returned ID and the original data
@@ -78,22 +100,50 @@ func (c *SDKClient) CreateAddress(ctx context.Context, address *Address) (*Addre
 func (c *SDKClient) GetAddress(ctx context.Context, addressID string) (*Address, error) {
 	response, err := c.Client.Address.Get(ctx, addressID)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving address: %v", err)
+		return nil, wrapError("error retrieving address", err)
 	}
 
-	// Convert response to our Address struct
-	address := &Address{
-		ID:      addressID,
-		Name:    response.Data.Name,
-		Street1: response.Data.Street1,
-		City:    response.Data.City,
-		Country: response.Data.Country,
-		Zip:     response.Data.Zip,
-		Street2: response.Data.Street2,
-		State:   response.Data.Province,
+	return addressFromSDK(response.Data), nil
+}
+
+// ListAddresses retrieves all shipping addresses for the current user
+func (c *SDKClient) ListAddresses(ctx context.Context) ([]*Address, error) {
+	response, err := c.Client.Address.List(ctx)
+	if err != nil {
+		return nil, wrapError("error listing addresses", err)
 	}
 
-	return address, nil
+	addresses := make([]*Address, len(response.Data))
+	for i, a := range response.Data {
+		addresses[i] = addressFromSDK(a)
+	}
+
+	return addresses, nil
+}
+
+// addressFromSDK converts an SDK address to our Address struct
+func addressFromSDK(a terminal.Address) *Address {
+	return &Address{
+		ID:      a.ID,
+		Name:    a.Name,
+		Street1: a.Street1,
+		City:    a.City,
+		Country: a.Country,
+		Zip:     a.Zip,
+		Street2: a.Street2,
+		State:   a.Province,
+		Phone:   a.Phone,
+	}
+}
+
+// DeleteAddress deletes a shipping address
+func (c *SDKClient) DeleteAddress(ctx context.Context, addressID string) error {
+	_, err := c.Client.Address.Delete(ctx, addressID)
+	if err != nil {
+		return wrapError("error deleting address", err)
+	}
+
+	return nil
 }
 
 // CreateCard creates a new payment card using a Stripe token
@@ -104,7 +154,7 @@ func (c *SDKClient) CreateCard(ctx context.Context, card *Card) (*Card, error) {
 
 	response, err := c.Client.Card.New(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("error creating card: %v", err)
+		return nil, wrapError("error creating card", err)
 	}
 
 	// Create a new card with the returned ID and token
@@ -120,19 +170,57 @@ func (c *SDKClient) CreateCard(ctx context.Context, card *Card) (*Card, error) {
 func (c *SDKClient) GetCard(ctx context.Context, cardID string) (*Card, error) {
 	response, err := c.Client.Card.Get(ctx, cardID)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving card: %v", err)
+		return nil, wrapError("error retrieving card", err)
+	}
+
+	return cardFromSDK(response.Data), nil
+}
+
+// ListCards retrieves all payment cards for the current user
+func (c *SDKClient) ListCards(ctx context.Context) ([]*Card, error) {
+	response, err := c.Client.Card.List(ctx)
+	if err != nil {
+		return nil, wrapError("error listing cards", err)
+	}
+
+	cards := make([]*Card, len(response.Data))
+	for i, card := range response.Data {
+		cards[i] = cardFromSDK(card)
+	}
+
+	return cards, nil
+}
+
+// cardFromSDK converts an SDK card to our Card struct
+func cardFromSDK(card terminal.Card) *Card {
+	return &Card{
+		ID:       card.ID,
+		Brand:    card.Brand,
+		Last4:    card.Last4,
+		ExpYear:  int(card.Expiration.Year),
+		ExpMonth: int(card.Expiration.Month),
+	}
+}
+
+// DeleteCard deletes a payment card
+func (c *SDKClient) DeleteCard(ctx context.Context, cardID string) error {
+	_, err := c.Client.Card.Delete(ctx, cardID)
+	if err != nil {
+		return wrapError("error deleting card", err)
 	}
 
-	// Convert response to our Card struct
-	card := &Card{
-		ID:       cardID,
-		Brand:    response.Data.Brand,
-		Last4:    response.Data.Last4,
-		ExpYear:  int(response.Data.Expiration.Year),
-		ExpMonth: int(response.Data.Expiration.Month),
+	return nil
+}
+
+// CollectCard returns a temporary URL at terminal.shop where a card can be
+// entered, so its details never pass through Terraform
+func (c *SDKClient) CollectCard(ctx context.Context) (string, error) {
+	response, err := c.Client.Card.Collect(ctx)
+	if err != nil {
+		return "", wrapError("error creating card collection URL", err)
 	}
 
-	return card, nil
+	return response.Data.URL, nil
 }
 
 // CreateOrder creates a new coffee order
@@ -149,17 +237,25 @@ func (c *SDKClient) CreateOrder(ctx context.Context, order *Order) (*Order, erro
 		Variants:  terminal.F(variants),
 	}
 
-	response, err := c.Client.Order.New(ctx, params)
+	// The key lets an API that records it recognise a repeated order. The
+	// request itself is never retried, since the key may not be honoured.
+	var opts []option.RequestOption
+	if order.IdempotencyKey != "" {
+		opts = append(opts, option.WithHeader("Idempotency-Key", order.IdempotencyKey))
+	}
+
+	response, err := c.Client.Order.New(ctx, params, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error creating order: %v", err)
+		return nil, wrapError("error creating order", err)
 	}
 
 	// Create a new order with the returned ID
 	createdOrder := &Order{
-		ID:        response.Data,
-		AddressID: order.AddressID,
-		CardID:    order.CardID,
-		Variants:  order.Variants,
+		ID:             response.Data,
+		AddressID:      order.AddressID,
+		CardID:         order.CardID,
+		Variants:       order.Variants,
+		IdempotencyKey: order.IdempotencyKey,
 	}
 
 	return createdOrder, nil
@@ -169,70 +265,563 @@ func (c *SDKClient) CreateOrder(ctx context.Context, order *Order) (*Order, erro
 func (c *SDKClient) GetOrder(ctx context.Context, orderID string) (*Order, error) {
 	response, err := c.Client.Order.Get(ctx, orderID)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving order: %v", err)
+		return nil, wrapError("error retrieving order", err)
 	}
 
-	// The SDK doesn't directly map to our original Order struct, so we need to extract the data we need
-	
-	// Convert items to map[string]any
-	items := make([]map[string]any, len(response.Data.Items))
-	for i, item := range response.Data.Items {
-		itemMap := make(map[string]any)
-		itemMap["id"] = item.ID
-		itemMap["amount"] = item.Amount
-		itemMap["quantity"] = item.Quantity
-		if item.Description != "" {
-			itemMap["description"] = item.Description
+	return orderFromSDK(response.Data), nil
+}
+
+// ListOrders retrieves every order placed by the current user
+func (c *SDKClient) ListOrders(ctx context.Context) ([]*Order, error) {
+	response, err := c.Client.Order.List(ctx)
+	if err != nil {
+		return nil, wrapError("error listing orders", err)
+	}
+
+	orders := make([]*Order, len(response.Data))
+	for i, o := range response.Data {
+		orders[i] = orderFromSDK(o)
+	}
+
+	return orders, nil
+}
+
+// orderFromSDK converts an SDK order to our Order struct
+func orderFromSDK(o terminal.Order) *Order {
+	// Convert items, collecting the ordered variants as we go
+	items := make([]OrderItem, len(o.Items))
+	variants := make(map[string]int)
+	for i, item := range o.Items {
+		items[i] = OrderItem{
+			ID:               item.ID,
+			Amount:           item.Amount,
+			Quantity:         item.Quantity,
+			Description:      item.Description,
+			ProductVariantID: item.ProductVariantID,
 		}
 		if item.ProductVariantID != "" {
-			itemMap["productVariantID"] = item.ProductVariantID
+			variants[item.ProductVariantID] += int(item.Quantity)
 		}
-		items[i] = itemMap
 	}
 
-	// Convert address to map[string]any from OrderShipping
-	address := make(map[string]any)
-	address["name"] = response.Data.Shipping.Name
-	address["street1"] = response.Data.Shipping.Street1
-	address["city"] = response.Data.Shipping.City
-	address["country"] = response.Data.Shipping.Country
-	address["zip"] = response.Data.Shipping.Zip
-	
-	if response.Data.Shipping.Street2 != "" {
-		address["street2"] = response.Data.Shipping.Street2
+	tracking := OrderTracking{
+		Number:  o.Tracking.Number,
+		Service: o.Tracking.Service,
+		URL:     o.Tracking.URL,
 	}
-	if response.Data.Shipping.Province != "" {
-		address["province"] = response.Data.Shipping.Province
+
+	return &Order{
+		ID:     o.ID,
+		Status: orderStatus(tracking, jsonString(o.Tracking.JSON.ExtraFields["status"].Raw())),
+		Total:  float64(o.Amount.Subtotal+o.Amount.Shipping) / 100.0, // convert cents to dollars
+		Amount: OrderAmount{
+			Subtotal: o.Amount.Subtotal,
+			Shipping: o.Amount.Shipping,
+		},
+		// The SDK doesn't model the creation time, so this is "" unless the
+		// API sends it
+		CreatedAt: jsonString(o.JSON.ExtraFields["created"].Raw()),
+		Variants:  variants,
+		Items:     items,
+		Shipping: OrderShipping{
+			Name:     o.Shipping.Name,
+			Street1:  o.Shipping.Street1,
+			Street2:  o.Shipping.Street2,
+			City:     o.Shipping.City,
+			Province: o.Shipping.Province,
+			Zip:      o.Shipping.Zip,
+			Country:  o.Shipping.Country,
+			Phone:    o.Shipping.Phone,
+		},
+		Tracking: tracking,
+		Index:    o.Index,
+		// Orders don't record the saved address and card they were placed
+		// with, or the idempotency key
 	}
-	if response.Data.Shipping.Phone != "" {
-		address["phone"] = response.Data.Shipping.Phone
+}
+
+// Order lifecycle statuses, derived from the tracking details
+const (
+	OrderStatusPlaced    = "placed"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+)
+
+// orderStatus derives an order's lifecycle status. An order has shipped once
+// it has a tracking number or URL, both of which the SDK models. Delivery is
+// best-effort: it is only known from the carrier's tracking status, which the
+// SDK doesn't model and the API may not send.
+func orderStatus(tracking OrderTracking, carrierStatus string) string {
+	switch {
+	case strings.EqualFold(carrierStatus, OrderStatusDelivered):
+		return OrderStatusDelivered
+	case tracking.Number != "" || tracking.URL != "":
+		return OrderStatusShipped
+	default:
+		return OrderStatusPlaced
 	}
+}
 
-	// For our original structure, let's set some defaults
-	total := float64(response.Data.Amount.Subtotal + response.Data.Amount.Shipping) / 100.0 // convert cents to dollars
-	
-	// Create the order with all received data
-	order := &Order{
-		ID:        orderID,
-		Status:    response.Data.Tracking.Service, // use service as status
-		Total:     total,
-		CreatedAt: "", // SDK doesn't appear to have a createdAt field
+// jsonString decodes the raw JSON of a field the SDK doesn't model, such as an
+// order's creation time. It returns "" if the field is missing or not a string.
+func jsonString(raw string) string {
+	var value string
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// GetCart retrieves the current user's cart
+func (c *SDKClient) GetCart(ctx context.Context) (*Cart, error) {
+	response, err := c.Client.Cart.Get(ctx)
+	if err != nil {
+		return nil, wrapError("error retrieving cart", err)
+	}
+
+	return cartFromSDK(response.Data), nil
+}
+
+// SetCartItem sets the quantity of a product variant in the cart. A quantity
+// of zero removes the variant.
+func (c *SDKClient) SetCartItem(ctx context.Context, variantID string, quantity int) (*Cart, error) {
+	params := terminal.CartSetItemParams{
+		ProductVariantID: terminal.String(variantID),
+		Quantity:         terminal.Int(int64(quantity)),
+	}
+
+	response, err := c.Client.Cart.SetItem(ctx, params)
+	if err != nil {
+		return nil, wrapError("error setting cart item", err)
+	}
+
+	return cartFromSDK(response.Data), nil
+}
+
+// SetCartAddress sets the shipping address of the cart
+func (c *SDKClient) SetCartAddress(ctx context.Context, addressID string) error {
+	params := terminal.CartSetAddressParams{
+		AddressID: terminal.String(addressID),
+	}
+
+	_, err := c.Client.Cart.SetAddress(ctx, params)
+	if err != nil {
+		return wrapError("error setting cart address", err)
+	}
+
+	return nil
+}
+
+// SetCartCard sets the payment card of the cart
+func (c *SDKClient) SetCartCard(ctx context.Context, cardID string) error {
+	params := terminal.CartSetCardParams{
+		CardID: terminal.String(cardID),
+	}
+
+	_, err := c.Client.Cart.SetCard(ctx, params)
+	if err != nil {
+		return wrapError("error setting cart card", err)
+	}
+
+	return nil
+}
+
+// ClearCart empties the cart, including its address and card
+func (c *SDKClient) ClearCart(ctx context.Context) error {
+	_, err := c.Client.Cart.Clear(ctx)
+	if err != nil {
+		return wrapError("error clearing cart", err)
+	}
+
+	return nil
+}
+
+// ConvertCart places an order for the contents of the cart, which is emptied
+// afterwards. Like CreateOrder it is never retried.
+func (c *SDKClient) ConvertCart(ctx context.Context) (*Order, error) {
+	response, err := c.Client.Cart.Convert(ctx)
+	if err != nil {
+		return nil, wrapError("error converting cart", err)
+	}
+
+	return orderFromSDK(response.Data), nil
+}
+
+// cartFromSDK converts an SDK cart to our Cart struct
+func cartFromSDK(cart terminal.Cart) *Cart {
+	items := make([]CartItem, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = CartItem{
+			ID:               item.ID,
+			ProductVariantID: item.ProductVariantID,
+			Quantity:         int(item.Quantity),
+			Subtotal:         item.Subtotal,
+		}
+	}
+
+	return &Cart{
 		Items:     items,
-		Address:   address,
-		// CardID and AddressID aren't directly available in the SDK response
+		AddressID: cart.AddressID,
+		CardID:    cart.CardID,
+		Subtotal:  cart.Amount.Subtotal,
+		Shipping:  cart.Amount.Shipping,
+		Total:     cart.Amount.Total,
 	}
-	
-	// Add tracking info if available
-	if response.Data.Tracking.Number != "" {
-		if order.Card == nil {
-			order.Card = make(map[string]any)
+}
+
+// CreateSubscription creates a new recurring subscription
+func (c *SDKClient) CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	// The API only acknowledges creation with "ok", so we snapshot the existing
+	// subscriptions beforehand and pick out the new one afterwards
+	existing, err := c.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, s := range existing {
+		seen[s.ID] = true
+	}
+
+	params := terminal.SubscriptionNewParams{
+		Subscription: terminal.SubscriptionParam{
+			AddressID:        terminal.String(subscription.AddressID),
+			CardID:           terminal.String(subscription.CardID),
+			ProductVariantID: terminal.String(subscription.ProductVariantID),
+			Quantity:         terminal.Int(int64(subscription.Quantity)),
+			Schedule:         terminal.F(subscriptionScheduleParam(subscription.Schedule)),
+		},
+	}
+
+	_, err = c.Client.Subscription.New(ctx, params)
+	if err != nil {
+		return nil, wrapError("error creating subscription", err)
+	}
+
+	// The subscription now exists and is billing, so keep looking for it
+	// rather than giving up on the first failed or stale listing
+	lookupErr := ctx.Err()
+	for attempt := 0; attempt <= c.Retry.MaxRetries && ctx.Err() == nil; attempt++ {
+		if attempt > 0 {
+			wait, _ := retryWait(nil, attempt-1, c.Retry, time.Now())
+			select {
+			case <-ctx.Done():
+				lookupErr = ctx.Err()
+				continue
+			case <-time.After(wait):
+			}
+		}
+
+		current, err := c.ListSubscriptions(ctx)
+		if err != nil {
+			lookupErr = err
+			continue
+		}
+		if created := newSubscription(current, seen, subscription); created != nil {
+			return created, nil
+		}
+		lookupErr = errors.New("it isn't listed")
+	}
+
+	return nil, fmt.Errorf("error creating subscription: the subscription was created but its ID couldn't be found (%v). "+
+		"Cancel it in the Terminal account, or import it with terraform import, before applying again", lookupErr)
+}
+
+// newSubscription picks the subscription that was just created out of the
+// current list, given the IDs seen beforehand. A new subscription with the
+// requested details is preferred, falling back to the only new one.
+func newSubscription(current []*Subscription, seen map[string]bool, subscription *Subscription) *Subscription {
+	var unseen []*Subscription
+	for _, s := range current {
+		if seen[s.ID] {
+			continue
+		}
+		if s.ProductVariantID == subscription.ProductVariantID &&
+			s.AddressID == subscription.AddressID &&
+			s.CardID == subscription.CardID &&
+			s.Quantity == subscription.Quantity {
+			return s
+		}
+		unseen = append(unseen, s)
+	}
+
+	if len(unseen) == 1 {
+		return unseen[0]
+	}
+
+	return nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (c *SDKClient) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	response, err := c.Client.Subscription.Get(ctx, subscriptionID)
+	if err != nil {
+		return nil, wrapError("error retrieving subscription", err)
+	}
+
+	return subscriptionFromSDK(response.Data), nil
+}
+
+// ListSubscriptions retrieves all subscriptions for the current user
+func (c *SDKClient) ListSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	response, err := c.Client.Subscription.List(ctx)
+	if err != nil {
+		return nil, wrapError("error listing subscriptions", err)
+	}
+
+	subscriptions := make([]*Subscription, len(response.Data))
+	for i, s := range response.Data {
+		subscriptions[i] = subscriptionFromSDK(s)
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateSubscription changes an existing subscription. The API has no update
+// endpoint, so the replacement is created first and the old subscription is
+// cancelled afterwards to avoid missing a delivery. If cancelling fails, the
+// replacement is still returned along with the error, since it is live.
+func (c *SDKClient) UpdateSubscription(ctx context.Context, subscriptionID string, subscription *Subscription) (*Subscription, error) {
+	updated, err := c.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.DeleteSubscription(ctx, subscriptionID); err != nil && !errors.Is(err, ErrNotFound) {
+		return updated, err
+	}
+
+	return updated, nil
+}
+
+// DeleteSubscription cancels a subscription
+func (c *SDKClient) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	_, err := c.Client.Subscription.Delete(ctx, subscriptionID)
+	if err != nil {
+		return wrapError("error cancelling subscription", err)
+	}
+
+	return nil
+}
+
+// subscriptionScheduleParam converts our schedule to the SDK union param
+func subscriptionScheduleParam(schedule SubscriptionSchedule) terminal.SubscriptionScheduleUnionParam {
+	if schedule.Type == string(terminal.SubscriptionScheduleTypeWeekly) {
+		return terminal.SubscriptionScheduleWeeklyParam{
+			Type:     terminal.F(terminal.SubscriptionScheduleWeeklyTypeWeekly),
+			Interval: terminal.Int(int64(schedule.Interval)),
+		}
+	}
+
+	return terminal.SubscriptionScheduleFixedParam{
+		Type: terminal.F(terminal.SubscriptionScheduleFixedTypeFixed),
+	}
+}
+
+// subscriptionFromSDK converts an SDK subscription to our Subscription struct
+func subscriptionFromSDK(s terminal.Subscription) *Subscription {
+	return &Subscription{
+		ID:               s.ID,
+		ProductVariantID: s.ProductVariantID,
+		Quantity:         int(s.Quantity),
+		AddressID:        s.AddressID,
+		CardID:           s.CardID,
+		Next:             s.Next,
+		Schedule: SubscriptionSchedule{
+			Type:     string(s.Schedule.Type),
+			Interval: int(s.Schedule.Interval),
+		},
+	}
+}
+
+// ListProducts retrieves every product in the Terminal catalog
+func (c *SDKClient) ListProducts(ctx context.Context) ([]*Product, error) {
+	response, err := c.Client.Product.List(ctx)
+	if err != nil {
+		return nil, wrapError("error listing products", err)
+	}
+
+	products := make([]*Product, len(response.Data))
+	for i, p := range response.Data {
+		products[i] = productFromSDK(p)
+	}
+
+	return products, nil
+}
+
+// GetProduct retrieves a product by ID
+func (c *SDKClient) GetProduct(ctx context.Context, productID string) (*Product, error) {
+	response, err := c.Client.Product.Get(ctx, productID)
+	if err != nil {
+		return nil, wrapError("error retrieving product", err)
+	}
+
+	return productFromSDK(response.Data), nil
+}
+
+// productFromSDK converts an SDK product to our Product struct
+func productFromSDK(p terminal.Product) *Product {
+	variants := make([]ProductVariant, len(p.Variants))
+	for i, v := range p.Variants {
+		variants[i] = ProductVariant{
+			ID:    v.ID,
+			Name:  v.Name,
+			Price: int(v.Price),
 		}
-		order.Card["tracking_number"] = response.Data.Tracking.Number
-		order.Card["tracking_service"] = response.Data.Tracking.Service
-		order.Card["tracking_url"] = response.Data.Tracking.URL
 	}
 
-	return order, nil
+	return &Product{
+		ID:           p.ID,
+		Name:         p.Name,
+		Description:  p.Description,
+		Subscription: string(p.Subscription),
+		Tags: ProductTags{
+			App:      p.Tags.App,
+			Color:    p.Tags.Color,
+			Featured: p.Tags.Featured,
+			MarketEU: p.Tags.MarketEu,
+			MarketNA: p.Tags.MarketNa,
+		},
+		Variants: variants,
+	}
+}
+
+// GetProfile retrieves the profile of the current user
+func (c *SDKClient) GetProfile(ctx context.Context) (*Profile, error) {
+	response, err := c.Client.Profile.Me(ctx)
+	if err != nil {
+		return nil, wrapError("error retrieving profile", err)
+	}
+
+	return profileFromSDK(response.Data.User), nil
+}
+
+// UpdateProfile sets the name and email of the current user
+func (c *SDKClient) UpdateProfile(ctx context.Context, profile *Profile) (*Profile, error) {
+	params := terminal.ProfileUpdateParams{
+		Name:  terminal.String(profile.Name),
+		Email: terminal.String(profile.Email),
+	}
+
+	response, err := c.Client.Profile.Update(ctx, params)
+	if err != nil {
+		return nil, wrapError("error updating profile", err)
+	}
+
+	return profileFromSDK(response.Data.User), nil
+}
+
+// profileFromSDK converts an SDK user to our Profile struct
+func profileFromSDK(user terminal.ProfileUser) *Profile {
+	return &Profile{
+		ID:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+	}
+}
+
+// CreateToken mints a personal access token for the current user. The token
+// value is only ever returned here.
+func (c *SDKClient) CreateToken(ctx context.Context) (*PersonalAccessToken, error) {
+	response, err := c.Client.Token.New(ctx)
+	if err != nil {
+		return nil, wrapError("error creating token", err)
+	}
+
+	return &PersonalAccessToken{
+		ID:    response.Data.ID,
+		Token: response.Data.Token,
+	}, nil
+}
+
+// GetToken retrieves a personal access token by ID. The API masks the token
+// value.
+func (c *SDKClient) GetToken(ctx context.Context, tokenID string) (*PersonalAccessToken, error) {
+	response, err := c.Client.Token.Get(ctx, tokenID)
+	if err != nil {
+		return nil, wrapError("error retrieving token", err)
+	}
+
+	return &PersonalAccessToken{
+		ID:      response.Data.ID,
+		Token:   response.Data.Token,
+		Created: response.Data.Created,
+	}, nil
+}
+
+// DeleteToken revokes a personal access token
+func (c *SDKClient) DeleteToken(ctx context.Context, tokenID string) error {
+	_, err := c.Client.Token.Delete(ctx, tokenID)
+	if err != nil {
+		return wrapError("error deleting token", err)
+	}
+
+	return nil
+}
+
+// CreateApp registers an OAuth app. The client secret is only ever returned
+// here.
+func (c *SDKClient) CreateApp(ctx context.Context, app *App) (*App, error) {
+	params := terminal.AppNewParams{
+		Name:        terminal.String(app.Name),
+		RedirectUri: terminal.String(app.RedirectURI),
+	}
+
+	response, err := c.Client.App.New(ctx, params)
+	if err != nil {
+		return nil, wrapError("error creating app", err)
+	}
+
+	createdApp := *app
+	createdApp.ID = response.Data.ID
+	createdApp.Secret = response.Data.Secret
+
+	return &createdApp, nil
+}
+
+// GetApp retrieves an OAuth app by ID. The API masks the client secret.
+func (c *SDKClient) GetApp(ctx context.Context, appID string) (*App, error) {
+	response, err := c.Client.App.Get(ctx, appID)
+	if err != nil {
+		return nil, wrapError("error retrieving app", err)
+	}
+
+	return appFromSDK(response.Data), nil
+}
+
+// ListApps retrieves all OAuth apps of the current user
+func (c *SDKClient) ListApps(ctx context.Context) ([]*App, error) {
+	response, err := c.Client.App.List(ctx)
+	if err != nil {
+		return nil, wrapError("error listing apps", err)
+	}
+
+	apps := make([]*App, len(response.Data))
+	for i, a := range response.Data {
+		apps[i] = appFromSDK(a)
+	}
+
+	return apps, nil
+}
+
+// appFromSDK converts an SDK app to our App struct
+func appFromSDK(a terminal.App) *App {
+	return &App{
+		ID:          a.ID,
+		Name:        a.Name,
+		RedirectURI: a.RedirectUri,
+		Secret:      a.Secret,
+	}
+}
+
+// DeleteApp deletes an OAuth app
+func (c *SDKClient) DeleteApp(ctx context.Context, appID string) error {
+	_, err := c.Client.App.Delete(ctx, appID)
+	if err != nil {
+		return wrapError("error deleting app", err)
+	}
+
+	return nil
 }
 
 // These structs match our existing data model but will be converted to/from SDK types
@@ -247,6 +836,7 @@ type Address struct {
 	State   string `json:"state,omitempty"`
 	Zip     string `json:"zip"`
 	Country string `json:"country"`
+	Phone   string `json:"phone,omitempty"`
 }
 
 // Card represents a payment card
@@ -261,16 +851,143 @@ type Card struct {
 
 // Order represents a coffee order
 type Order struct {
-	ID        string            `json:"id,omitempty"`
-	AddressID string            `json:"addressID,omitempty"`
-	CardID    string            `json:"cardID,omitempty"`
-	Variants  map[string]int    `json:"variants,omitempty"`
-	Status    string            `json:"status,omitempty"`
-	Total     float64           `json:"total,omitempty"`
-	CreatedAt string            `json:"createdAt,omitempty"`
-	Items     []map[string]any  `json:"items,omitempty"`
-	Address   map[string]any    `json:"address,omitempty"`
-	Card      map[string]any    `json:"card,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	AddressID string         `json:"addressID,omitempty"`
+	CardID    string         `json:"cardID,omitempty"`
+	Variants  map[string]int `json:"variants,omitempty"`
+	Status    string         `json:"status,omitempty"` // placed, shipped or delivered
+	Total     float64        `json:"total,omitempty"`  // in dollars
+	Amount    OrderAmount    `json:"amount"`
+	CreatedAt string         `json:"createdAt,omitempty"` // RFC 3339
+	Items     []OrderItem    `json:"items,omitempty"`
+	Shipping  OrderShipping  `json:"shipping"`
+	Tracking  OrderTracking  `json:"tracking"`
+	// Index is the zero-based position of the order in the account's order
+	// history, so later orders have higher indexes
+	Index int64 `json:"index"`
+	// IdempotencyKey is sent as a header when placing the order. The API
+	// doesn't return it.
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+}
+
+// OrderAmount is the breakdown of an order's charge, in cents (USD)
+type OrderAmount struct {
+	Subtotal int64 `json:"subtotal"`
+	Shipping int64 `json:"shipping"`
+}
+
+// OrderShipping is the address an order was shipped to
+type OrderShipping struct {
+	Name     string `json:"name"`
+	Street1  string `json:"street1"`
+	Street2  string `json:"street2,omitempty"`
+	City     string `json:"city"`
+	Province string `json:"province,omitempty"`
+	Zip      string `json:"zip"`
+	Country  string `json:"country"`
+	Phone    string `json:"phone,omitempty"`
+}
+
+// OrderTracking holds the carrier details of an order. The number and URL are
+// only set once the order has shipped.
+type OrderTracking struct {
+	Number  string `json:"number,omitempty"`
+	Service string `json:"service,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// OrderItem is a single line of an order
+type OrderItem struct {
+	ID               string `json:"id"`
+	Amount           int64  `json:"amount"`
+	Quantity         int64  `json:"quantity"`
+	Description      string `json:"description,omitempty"`
+	ProductVariantID string `json:"productVariantID,omitempty"`
+}
+
+// Cart is the current user's server-side cart, which is turned into an order
+// when converted. Amounts are in cents (USD).
+type Cart struct {
+	Items     []CartItem `json:"items"`
+	AddressID string     `json:"addressID,omitempty"`
+	CardID    string     `json:"cardID,omitempty"`
+	Subtotal  int64      `json:"subtotal"`
+	Shipping  int64      `json:"shipping"`
+	Total     int64      `json:"total"`
+}
+
+// CartItem is a single product variant in the cart
+type CartItem struct {
+	ID               string `json:"id"`
+	ProductVariantID string `json:"productVariantID"`
+	Quantity         int    `json:"quantity"`
+	Subtotal         int64  `json:"subtotal"` // in cents (USD)
+}
+
+// Subscription represents a recurring coffee delivery
+type Subscription struct {
+	ID               string               `json:"id,omitempty"`
+	ProductVariantID string               `json:"productVariantID"`
+	Quantity         int                  `json:"quantity"`
+	AddressID        string               `json:"addressID"`
+	CardID           string               `json:"cardID"`
+	Schedule         SubscriptionSchedule `json:"schedule"`
+	Next             string               `json:"next,omitempty"`
+}
+
+// SubscriptionSchedule describes how often a subscription ships
+type SubscriptionSchedule struct {
+	Type     string `json:"type"`
+	Interval int    `json:"interval,omitempty"`
+}
+
+// Product represents a product in the Terminal catalog
+type Product struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Subscription string           `json:"subscription,omitempty"`
+	Tags         ProductTags      `json:"tags"`
+	Variants     []ProductVariant `json:"variants"`
+}
+
+// ProductTags holds the display and market tags of a product
+type ProductTags struct {
+	App      string `json:"app,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Featured bool   `json:"featured,omitempty"`
+	MarketEU bool   `json:"market_eu,omitempty"`
+	MarketNA bool   `json:"market_na,omitempty"`
+}
+
+// ProductVariant represents a purchasable variant of a product
+type ProductVariant struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Price int    `json:"price"` // in cents (USD)
+}
+
+// Profile is the identity of the account the API token belongs to
+type Profile struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// PersonalAccessToken is an API token minted for the current user
+type PersonalAccessToken struct {
+	ID      string `json:"id"`
+	Token   string `json:"token"`
+	Created string `json:"created,omitempty"` // RFC 3339
+}
+
+// App is an OAuth 2.0 client registered with Terminal. Its ID is the client
+// ID.
+type App struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	RedirectURI string `json:"redirectURI"`
+	Secret      string `json:"secret,omitempty"`
 }
 
 // Helper function to convert string quantity to int
@@ -2,23 +2,31 @@ package terminal
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 	
+	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal/mockapi"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/terminaldotshop/terminal-sdk-go"
 	"github.com/terminaldotshop/terminal-sdk-go/option"
 )
 
-// Note: These tests need a valid TEST_TERMINAL_API_TOKEN environment variable 
-// to run against the development environment. Otherwise they will be skipped.
+// Note: These tests run against the development environment when a valid
+// TEST_TERMINAL_API_TOKEN environment variable is set. Otherwise they run
+// against the in-memory mock API from the mockapi package.
 
-// getTestClient returns a client for testing against the dev environment
-// or skips the test if no API token is available
+// getTestClient returns a client for testing against the dev environment,
+// falling back to the mock API if no API token is available
 func getTestClient(t *testing.T) *SDKClient {
 	apiToken := os.Getenv("TEST_TERMINAL_API_TOKEN")
 	if apiToken == "" {
-		t.Skip("Skipping test: TEST_TERMINAL_API_TOKEN environment variable not set")
+		t.Log("TEST_TERMINAL_API_TOKEN environment variable not set, using the mock API")
+		return getMockClient(t)
 	}
 
 	// Log details for debugging
@@ -47,6 +55,19 @@ func getTestClient(t *testing.T) *SDKClient {
 	return client
 }
 
+// getMockClient returns a client pointed at a fresh mock API server
+func getMockClient(t *testing.T) *SDKClient {
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+
+	return client
+}
+
 func TestSdkClientInit(t *testing.T) {
 	// Test creating a client with default production endpoint
 	client, err := NewClient("https://api.terminal.shop", "test-token")
@@ -78,8 +99,6 @@ func TestSdkClientInit(t *testing.T) {
 
 // TestProviderDevConfig tests that the provider correctly handles the dev environment flag
 func TestProviderDevConfig(t *testing.T) {
-	// We'll test this indirectly since we don't have easy access to the schema.ResourceData internals
-	
 	// Mock environment variables
 	os.Setenv("TERMINAL_API_TOKEN", "test-token")
 	defer os.Unsetenv("TERMINAL_API_TOKEN")
@@ -120,23 +139,19 @@ func TestProviderDevConfig(t *testing.T) {
 	// Run test cases
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// We'll use the NewClient function directly since it mirrors the provider's logic
-			usedEndpoint := tc.apiEndpoint
-			if tc.useDevEnvironment {
-				usedEndpoint = "https://api.dev.terminal.shop"
-			}
-			
-			client, err := NewClient(usedEndpoint, "test-token")
-			if err != nil {
-				t.Fatalf("Failed to create client: %v", err)
+			// Configure the provider the same way Terraform would
+			client, diags := configureClient(terminalProviderModel{
+				APIEndpoint:       types.StringValue(tc.apiEndpoint),
+				UseDevEnvironment: types.BoolValue(tc.useDevEnvironment),
+				APIToken:          types.StringValue("test-token"),
+			})
+			if diags.HasError() {
+				t.Fatalf("Failed to configure provider: %v", diags)
 			}
-			
-			if client == nil {
-				t.Fatal("Client should not be nil")
+
+			if client.Endpoint != tc.expectedEndpoint {
+				t.Errorf("Expected endpoint %s, got %s", tc.expectedEndpoint, client.Endpoint)
 			}
-			
-			// Verify the client was created successfully
-			// We can't directly check the endpoint used, but we know the client creation succeeded
 		})
 	}
 }
@@ -248,8 +263,11 @@ func TestFullWorkflow(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Error getting order: %v", err)
 	}
-	if retrievedOrder.Status == "" {
-		t.Error("Retrieved order should have a status")
+	if retrievedOrder.Status != OrderStatusPlaced {
+		t.Errorf("Retrieved order should be placed, got status %q", retrievedOrder.Status)
+	}
+	if retrievedOrder.CreatedAt == "" {
+		t.Error("Retrieved order should have a creation time")
 	}
 	if retrievedOrder.Total == 0 {
 		t.Error("Retrieved order should have a non-zero total")
@@ -257,11 +275,309 @@ func TestFullWorkflow(t *testing.T) {
 	if len(retrievedOrder.Items) == 0 {
 		t.Error("Retrieved order should have items")
 	}
-	if retrievedOrder.Address == nil {
-		t.Error("Retrieved order should have address information")
+	if retrievedOrder.Shipping.Street1 != createdAddress.Street1 {
+		t.Errorf("Retrieved order should ship to %q, got %q", createdAddress.Street1, retrievedOrder.Shipping.Street1)
 	}
 	t.Logf("Successfully retrieved order: %+v", retrievedOrder)
 
 	// If we got here, the full workflow test passed successfully
 	t.Log("Full workflow test completed successfully")
-}
\ No newline at end of file
+}
+
+// TestSubscriptionWorkflow creates, replaces and cancels a subscription
+func TestSubscriptionWorkflow(t *testing.T) {
+	client := getMockClient(t)
+	ctx := context.Background()
+
+	address, err := client.CreateAddress(ctx, &Address{
+		Name:    "Test User",
+		Street1: "123 Test St",
+		City:    "Test City",
+		Zip:     "12345",
+		Country: "US",
+	})
+	if err != nil {
+		t.Fatalf("Error creating address: %v", err)
+	}
+	card, err := client.CreateCard(ctx, &Card{Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("Error creating card: %v", err)
+	}
+
+	subscription := &Subscription{
+		ProductVariantID: "var_9U04ZMMHXK",
+		Quantity:         1,
+		AddressID:        address.ID,
+		CardID:           card.ID,
+		Schedule:         SubscriptionSchedule{Type: "weekly", Interval: 2},
+	}
+	created, err := client.CreateSubscription(ctx, subscription)
+	if err != nil {
+		t.Fatalf("Error creating subscription: %v", err)
+	}
+	if created.ID == "" || created.Next == "" {
+		t.Fatalf("Created subscription should have an ID and next date: %+v", created)
+	}
+
+	subscription.Quantity = 3
+	updated, err := client.UpdateSubscription(ctx, created.ID, subscription)
+	if err != nil {
+		t.Fatalf("Error updating subscription: %v", err)
+	}
+	if updated.Quantity != 3 || updated.Schedule.Interval != 2 {
+		t.Errorf("Unexpected updated subscription: %+v", updated)
+	}
+
+	// The replaced subscription must be gone
+	if _, err := client.GetSubscription(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for the replaced subscription, got %v", err)
+	}
+
+	if err := client.DeleteSubscription(ctx, updated.ID); err != nil {
+		t.Fatalf("Error cancelling subscription: %v", err)
+	}
+	subscriptions, err := client.ListSubscriptions(ctx)
+	if err != nil {
+		t.Fatalf("Error listing subscriptions: %v", err)
+	}
+	if len(subscriptions) != 0 {
+		t.Errorf("Expected no subscriptions, got %d", len(subscriptions))
+	}
+}
+
+// newFlakyMockClient returns a client for a mock API whose requests are
+// first passed to fail, which can write an error response instead
+func newFlakyMockClient(t *testing.T, fail func(w http.ResponseWriter, r *http.Request) bool) *SDKClient {
+	api := mockapi.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !fail(w, r) {
+			api.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create mock client: %v", err)
+	}
+	client.Retry = retryPolicy{MaxRetries: 3, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond}
+
+	return client
+}
+
+// TestSubscriptionPartialFailures checks that a subscription that has been
+// created is returned even when a later step fails
+func TestSubscriptionPartialFailures(t *testing.T) {
+	var created, failCancel bool
+	staleLists := 0
+	client := newFlakyMockClient(t, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/subscription":
+			created = true
+		case r.Method == http.MethodGet && r.URL.Path == "/subscription" && created && staleLists > 0:
+			// The new subscription isn't listed straight away
+			staleLists--
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data": []}`))
+			return true
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/subscription/") && failCancel:
+			w.WriteHeader(http.StatusInternalServerError)
+			return true
+		}
+		return false
+	})
+	ctx := context.Background()
+
+	address, err := client.CreateAddress(ctx, &Address{
+		Name:    "Test User",
+		Street1: "123 Test St",
+		City:    "Test City",
+		State:   "CA",
+		Zip:     "12345",
+		Country: "US",
+	})
+	if err != nil {
+		t.Fatalf("Error creating address: %v", err)
+	}
+	card, err := client.CreateCard(ctx, &Card{Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("Error creating card: %v", err)
+	}
+	subscription := &Subscription{
+		ProductVariantID: "var_9U04ZMMHXK",
+		Quantity:         1,
+		AddressID:        address.ID,
+		CardID:           card.ID,
+		Schedule:         SubscriptionSchedule{Type: "fixed"},
+	}
+
+	// A stale listing is looked up again rather than orphaning the new
+	// subscription
+	staleLists = 2
+	original, err := client.CreateSubscription(ctx, subscription)
+	if err != nil {
+		t.Fatalf("Expected the new subscription to be found, got %v", err)
+	}
+	if original.ID == "" {
+		t.Fatal("Expected the new subscription's ID")
+	}
+
+	// Once the replacement is live, a failed cancel still returns it
+	created = false
+	failCancel = true
+	subscription.Quantity = 2
+	replacement, err := client.UpdateSubscription(ctx, original.ID, subscription)
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("Expected ErrServer from the failed cancel, got %v", err)
+	}
+	if replacement == nil || replacement.ID == original.ID || replacement.Quantity != 2 {
+		t.Fatalf("Expected the replacement subscription along with the error, got %+v", replacement)
+	}
+
+	// Creating a subscription that is never listed fails with guidance
+	failCancel = false
+	created = false
+	staleLists = 10
+	subscription.Quantity = 3
+	if _, err := client.CreateSubscription(ctx, subscription); err == nil || !strings.Contains(err.Error(), "was created but its ID couldn't be found") {
+		t.Errorf("Expected an error saying the subscription was created, got %v", err)
+	}
+}
+
+// TestClientErrors checks that API failures surface as typed errors
+func TestClientErrors(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.GetAddress(ctx, "shp_missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing address, got %v", err)
+	}
+	if _, err := client.GetOrder(ctx, "ord_missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing order, got %v", err)
+	}
+	if _, err := client.CreateAddress(ctx, &Address{Name: "Incomplete"}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for an incomplete address, got %v", err)
+	}
+
+	unauthorized, err := NewClient(server.URL, "wrong-token")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := unauthorized.ListProducts(ctx); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for a bad token, got %v", err)
+	}
+}
+
+// TestOrderLifecycle checks the status and tracking derived as an order ships
+// and is delivered
+func TestOrderLifecycle(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	address, err := client.CreateAddress(ctx, &Address{
+		Name:    "Test User",
+		Street1: "123 Test St",
+		City:    "Test City",
+		Zip:     "12345",
+		Country: "US",
+	})
+	if err != nil {
+		t.Fatalf("Error creating address: %v", err)
+	}
+	card, err := client.CreateCard(ctx, &Card{Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("Error creating card: %v", err)
+	}
+	created, err := client.CreateOrder(ctx, &Order{
+		AddressID: address.ID,
+		CardID:    card.ID,
+		Variants:  map[string]int{"var_9U04ZMMHXK": 2},
+	})
+	if err != nil {
+		t.Fatalf("Error creating order: %v", err)
+	}
+
+	order, err := client.GetOrder(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Error getting order: %v", err)
+	}
+	if order.Status != OrderStatusPlaced {
+		t.Errorf("Expected status %q, got %q", OrderStatusPlaced, order.Status)
+	}
+	if order.Amount.Subtotal != 4400 || order.Amount.Shipping != 0 {
+		t.Errorf("Expected amount {4400 0}, got %+v", order.Amount)
+	}
+	if order.Tracking.Number != "" {
+		t.Errorf("Expected no tracking number before shipping, got %q", order.Tracking.Number)
+	}
+
+	if err := server.API.ShipOrder(created.ID, "9400100000000000000000", "USPS", "https://tools.usps.com/go/TrackConfirmAction?tLabels=9400100000000000000000"); err != nil {
+		t.Fatalf("Error shipping order: %v", err)
+	}
+	order, err = client.GetOrder(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Error getting order: %v", err)
+	}
+	if order.Status != OrderStatusShipped {
+		t.Errorf("Expected status %q, got %q", OrderStatusShipped, order.Status)
+	}
+	if order.Tracking.Number != "9400100000000000000000" || order.Tracking.Service != "USPS" {
+		t.Errorf("Unexpected tracking %+v", order.Tracking)
+	}
+
+	if err := server.API.DeliverOrder(created.ID); err != nil {
+		t.Fatalf("Error delivering order: %v", err)
+	}
+	order, err = client.GetOrder(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Error getting order: %v", err)
+	}
+	if order.Status != OrderStatusDelivered {
+		t.Errorf("Expected status %q, got %q", OrderStatusDelivered, order.Status)
+	}
+}
+
+func TestOrderFromSDK(t *testing.T) {
+	// An order as the SDK models it, without the creation time or carrier
+	// status the API may add
+	var o terminal.Order
+	err := o.UnmarshalJSON([]byte(`{
+  "id": "ord_1",
+  "index": 0,
+  "amount": {"subtotal": 2200, "shipping": 0},
+  "items": [{"id": "itm_1", "amount": 2200, "quantity": 1, "productVariantID": "var_1"}],
+  "shipping": {"name": "Office", "street1": "2 Main St", "city": "Springfield", "zip": "12345", "country": "US"},
+  "tracking": {"service": "USPS", "url": "https://tools.usps.com/go/TrackConfirmAction?tLabels=9400"}
+}`))
+	if err != nil {
+		t.Fatalf("Error decoding order: %v", err)
+	}
+
+	order := orderFromSDK(o)
+	if order.CreatedAt != "" {
+		t.Errorf("Expected no creation time, got %q", order.CreatedAt)
+	}
+	// A tracking URL is enough to tell the order has shipped
+	if order.Status != OrderStatusShipped {
+		t.Errorf("Expected status %q, got %q", OrderStatusShipped, order.Status)
+	}
+
+	if status := orderStatus(OrderTracking{}, ""); status != OrderStatusPlaced {
+		t.Errorf("Expected status %q without tracking, got %q", OrderStatusPlaced, status)
+	}
+	if status := orderStatus(OrderTracking{Number: "9400"}, "Delivered"); status != OrderStatusDelivered {
+		t.Errorf("Expected status %q from the carrier, got %q", OrderStatusDelivered, status)
+	}
+}
@@ -4,81 +4,127 @@ import (
 	"context"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func dataSourceAddress() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataSourceAddressRead,
-		Schema: map[string]*schema.Schema{
-			"address_id": {
-				Type:        schema.TypeString,
+var _ datasource.DataSourceWithConfigure = &addressDataSource{}
+
+// addressDataSource looks up a saved shipping address by ID
+type addressDataSource struct {
+	client *SDKClient
+}
+
+type addressDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	AddressID types.String   `tfsdk:"address_id"`
+	Name      types.String   `tfsdk:"name"`
+	Street1   types.String   `tfsdk:"street1"`
+	Street2   types.String   `tfsdk:"street2"`
+	City      types.String   `tfsdk:"city"`
+	State     types.String   `tfsdk:"state"`
+	Zip       types.String   `tfsdk:"zip"`
+	Country   types.String   `tfsdk:"country"`
+	Phone     types.String   `tfsdk:"phone"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewAddressDataSource() datasource.DataSource {
+	return &addressDataSource{}
+}
+
+func (d *addressDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_address"
+}
+
+func (d *addressDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a saved shipping address",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the address",
+			},
+			"address_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The ID of the address to retrieve",
 			},
-			"name": {
-				Type:        schema.TypeString,
+			"name": schema.StringAttribute{
 				Computed:    true,
 				Description: "The name associated with the address",
 			},
-			"street1": {
-				Type:        schema.TypeString,
+			"street1": schema.StringAttribute{
 				Computed:    true,
 				Description: "The first line of the street address",
 			},
-			"street2": {
-				Type:        schema.TypeString,
+			"street2": schema.StringAttribute{
 				Computed:    true,
 				Description: "The second line of the street address",
 			},
-			"city": {
-				Type:        schema.TypeString,
+			"city": schema.StringAttribute{
 				Computed:    true,
 				Description: "The city name",
 			},
-			"state": {
-				Type:        schema.TypeString,
+			"state": schema.StringAttribute{
 				Computed:    true,
 				Description: "The state or province",
 			},
-			"zip": {
-				Type:        schema.TypeString,
+			"zip": schema.StringAttribute{
 				Computed:    true,
 				Description: "The zip or postal code",
 			},
-			"country": {
-				Type:        schema.TypeString,
+			"country": schema.StringAttribute{
 				Computed:    true,
 				Description: "The country code (e.g., US)",
 			},
+			"phone": schema.StringAttribute{
+				Computed:    true,
+				Description: "The recipient's phone number in E.164 format, if set",
+			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Read: schema.DefaultTimeout(5 * time.Minute),
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
-func dataSourceAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+func (d *addressDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
-	var diags diag.Diagnostics
+func (d *addressDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data addressDataSourceModel
 
-	addressID := d.Get("address_id").(string)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	address, err := client.GetAddress(ctx, addressID)
+	address, err := d.client.GetAddress(ctx, data.AddressID.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading address", err.Error())
+		return
 	}
 
-	d.SetId(address.ID)
-	d.Set("name", address.Name)
-	d.Set("street1", address.Street1)
-	d.Set("street2", address.Street2)
-	d.Set("city", address.City)
-	d.Set("state", address.State)
-	d.Set("zip", address.Zip)
-	d.Set("country", address.Country)
+	data.ID = types.StringValue(address.ID)
+	data.Name = types.StringValue(address.Name)
+	data.Street1 = types.StringValue(address.Street1)
+	data.Street2 = optionalString(address.Street2)
+	data.City = types.StringValue(address.City)
+	data.State = optionalString(address.State)
+	data.Zip = types.StringValue(address.Zip)
+	data.Country = types.StringValue(address.Country)
+	data.Phone = optionalString(address.Phone)
 
-	return diags
-}
\ No newline at end of file
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,247 @@
+package terminal
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &addressesDataSource{}
+
+// addressesDataSource lists the saved shipping addresses matching optional
+// filters
+type addressesDataSource struct {
+	client *SDKClient
+}
+
+type addressesDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	NameRegex types.String   `tfsdk:"name_regex"`
+	Country   types.String   `tfsdk:"country"`
+	Zip       types.String   `tfsdk:"zip"`
+	IDs       types.List     `tfsdk:"ids"`
+	Addresses types.List     `tfsdk:"addresses"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// addressModel is an address as exposed by the addresses data source
+type addressModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Street1 types.String `tfsdk:"street1"`
+	Street2 types.String `tfsdk:"street2"`
+	City    types.String `tfsdk:"city"`
+	State   types.String `tfsdk:"state"`
+	Zip     types.String `tfsdk:"zip"`
+	Country types.String `tfsdk:"country"`
+	Phone   types.String `tfsdk:"phone"`
+}
+
+var addressAttrTypes = map[string]attr.Type{
+	"id":      types.StringType,
+	"name":    types.StringType,
+	"street1": types.StringType,
+	"street2": types.StringType,
+	"city":    types.StringType,
+	"state":   types.StringType,
+	"zip":     types.StringType,
+	"country": types.StringType,
+	"phone":   types.StringType,
+}
+
+// addressFilter selects addresses by the data source's filter arguments.
+// Empty fields match every address.
+type addressFilter struct {
+	Name      string
+	NameRegex *regexp.Regexp
+	Country   string
+	Zip       string
+}
+
+func NewAddressesDataSource() datasource.DataSource {
+	return &addressesDataSource{}
+}
+
+func (d *addressesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_addresses"
+}
+
+func (d *addressesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the saved shipping addresses, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A static identifier for the list",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include addresses with exactly this name",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include addresses whose name matches this regular expression",
+				Validators:  []validator.String{validRegexp()},
+			},
+			"country": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include addresses in this country (e.g., US), ignoring case",
+			},
+			"zip": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include addresses with this zip or postal code",
+			},
+			"ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the matching addresses",
+			},
+			"addresses": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching addresses",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the address",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name associated with the address",
+						},
+						"street1": schema.StringAttribute{
+							Computed:    true,
+							Description: "The first line of the street address",
+						},
+						"street2": schema.StringAttribute{
+							Computed:    true,
+							Description: "The second line of the street address",
+						},
+						"city": schema.StringAttribute{
+							Computed:    true,
+							Description: "The city name",
+						},
+						"state": schema.StringAttribute{
+							Computed:    true,
+							Description: "The state or province",
+						},
+						"zip": schema.StringAttribute{
+							Computed:    true,
+							Description: "The zip or postal code",
+						},
+						"country": schema.StringAttribute{
+							Computed:    true,
+							Description: "The country code (e.g., US)",
+						},
+						"phone": schema.StringAttribute{
+							Computed:    true,
+							Description: "The recipient's phone number in E.164 format, if set",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *addressesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *addressesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data addressesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	filter := addressFilter{
+		Name:    data.Name.ValueString(),
+		Country: data.Country.ValueString(),
+		Zip:     data.Zip.ValueString(),
+	}
+	if nameRegex := data.NameRegex.ValueString(); nameRegex != "" {
+		re, err := regexp.Compile(nameRegex)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+		filter.NameRegex = re
+	}
+
+	addresses, err := d.client.ListAddresses(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing addresses", err.Error())
+		return
+	}
+
+	ids := []string{}
+	models := []addressModel{}
+	for _, address := range addresses {
+		if !filter.matches(address) {
+			continue
+		}
+
+		ids = append(ids, address.ID)
+		models = append(models, addressModel{
+			ID:      types.StringValue(address.ID),
+			Name:    types.StringValue(address.Name),
+			Street1: types.StringValue(address.Street1),
+			Street2: optionalString(address.Street2),
+			City:    types.StringValue(address.City),
+			State:   optionalString(address.State),
+			Zip:     types.StringValue(address.Zip),
+			Country: types.StringValue(address.Country),
+			Phone:   optionalString(address.Phone),
+		})
+	}
+
+	data.ID = types.StringValue("addresses")
+	data.IDs, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.Addresses, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: addressAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matches reports whether an address passes every filter that is set
+func (f addressFilter) matches(address *Address) bool {
+	switch {
+	case f.Name != "" && address.Name != f.Name:
+		return false
+	case f.NameRegex != nil && !f.NameRegex.MatchString(address.Name):
+		return false
+	case f.Country != "" && !strings.EqualFold(address.Country, f.Country):
+		return false
+	case f.Zip != "" && address.Zip != f.Zip:
+		return false
+	default:
+		return true
+	}
+}
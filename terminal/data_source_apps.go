@@ -0,0 +1,134 @@
+package terminal
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &appsDataSource{}
+
+// appsDataSource lists the OAuth apps of the current user
+type appsDataSource struct {
+	client *SDKClient
+}
+
+type appsDataSourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Apps     types.List     `tfsdk:"apps"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// appModel is an app as exposed by the apps data source. Client secrets are
+// masked by the API, so they are left out.
+type appModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	RedirectURI types.String `tfsdk:"redirect_uri"`
+	ClientID    types.String `tfsdk:"client_id"`
+}
+
+var appAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"redirect_uri": types.StringType,
+	"client_id":    types.StringType,
+}
+
+func NewAppsDataSource() datasource.DataSource {
+	return &appsDataSource{}
+}
+
+func (d *appsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apps"
+}
+
+func (d *appsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the OAuth apps of the current user",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A static identifier for the list",
+			},
+			"apps": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Every OAuth app of the current user. Client secrets are only available from the terminal_app resource that created them",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the app",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the app",
+						},
+						"redirect_uri": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URI users are sent back to after authorizing the app",
+						},
+						"client_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The OAuth client ID",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *appsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *appsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	apps, err := d.client.ListApps(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing apps", err.Error())
+		return
+	}
+
+	models := make([]appModel, len(apps))
+	for i, app := range apps {
+		models[i] = appModel{
+			ID:          types.StringValue(app.ID),
+			Name:        types.StringValue(app.Name),
+			RedirectURI: types.StringValue(app.RedirectURI),
+			ClientID:    types.StringValue(app.ID),
+		}
+	}
+
+	data.ID = types.StringValue("apps")
+	data.Apps, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: appAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
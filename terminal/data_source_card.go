@@ -4,63 +4,103 @@ import (
 	"context"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func dataSourceCard() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataSourceCardRead,
-		Schema: map[string]*schema.Schema{
-			"card_id": {
-				Type:        schema.TypeString,
+var _ datasource.DataSourceWithConfigure = &cardDataSource{}
+
+// cardDataSource looks up a saved payment card by ID
+type cardDataSource struct {
+	client *SDKClient
+}
+
+type cardDataSourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	CardID   types.String   `tfsdk:"card_id"`
+	Brand    types.String   `tfsdk:"brand"`
+	Last4    types.String   `tfsdk:"last4"`
+	ExpMonth types.Int64    `tfsdk:"exp_month"`
+	ExpYear  types.Int64    `tfsdk:"exp_year"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewCardDataSource() datasource.DataSource {
+	return &cardDataSource{}
+}
+
+func (d *cardDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_payment_card"
+}
+
+func (d *cardDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a saved payment card",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the payment card",
+			},
+			"card_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The ID of the payment card to retrieve",
 			},
-			"brand": {
-				Type:        schema.TypeString,
+			"brand": schema.StringAttribute{
 				Computed:    true,
 				Description: "The card brand (e.g., Visa, Mastercard)",
 			},
-			"last4": {
-				Type:        schema.TypeString,
+			"last4": schema.StringAttribute{
 				Computed:    true,
 				Description: "The last 4 digits of the card number",
 			},
-			"exp_month": {
-				Type:        schema.TypeInt,
+			"exp_month": schema.Int64Attribute{
 				Computed:    true,
 				Description: "The expiration month (1-12)",
 			},
-			"exp_year": {
-				Type:        schema.TypeInt,
+			"exp_year": schema.Int64Attribute{
 				Computed:    true,
 				Description: "The expiration year",
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Read: schema.DefaultTimeout(5 * time.Minute),
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
-func dataSourceCardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+func (d *cardDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *cardDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cardDataSourceModel
 
-	var diags diag.Diagnostics
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	cardID := d.Get("card_id").(string)
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	card, err := client.GetCard(ctx, cardID)
+	card, err := d.client.GetCard(ctx, data.CardID.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading payment card", err.Error())
+		return
 	}
 
-	d.SetId(card.ID)
-	d.Set("brand", card.Brand)
-	d.Set("last4", card.Last4)
-	d.Set("exp_month", card.ExpMonth)
-	d.Set("exp_year", card.ExpYear)
+	data.ID = types.StringValue(card.ID)
+	data.Brand = types.StringValue(card.Brand)
+	data.Last4 = types.StringValue(card.Last4)
+	data.ExpMonth = types.Int64Value(int64(card.ExpMonth))
+	data.ExpYear = types.Int64Value(int64(card.ExpYear))
 
-	return diags
-}
\ No newline at end of file
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
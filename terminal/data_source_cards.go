@@ -0,0 +1,209 @@
+package terminal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &cardsDataSource{}
+
+// cardsDataSource lists the saved payment cards matching optional filters
+type cardsDataSource struct {
+	client *SDKClient
+}
+
+type cardsDataSourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Brand          types.String   `tfsdk:"brand"`
+	Last4          types.String   `tfsdk:"last4"`
+	ExcludeExpired types.Bool     `tfsdk:"exclude_expired"`
+	IDs            types.List     `tfsdk:"ids"`
+	Cards          types.List     `tfsdk:"cards"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// cardModel is a card as exposed by the payment cards data source
+type cardModel struct {
+	ID       types.String `tfsdk:"id"`
+	Brand    types.String `tfsdk:"brand"`
+	Last4    types.String `tfsdk:"last4"`
+	ExpMonth types.Int64  `tfsdk:"exp_month"`
+	ExpYear  types.Int64  `tfsdk:"exp_year"`
+}
+
+var cardAttrTypes = map[string]attr.Type{
+	"id":        types.StringType,
+	"brand":     types.StringType,
+	"last4":     types.StringType,
+	"exp_month": types.Int64Type,
+	"exp_year":  types.Int64Type,
+}
+
+// cardFilter selects cards by the data source's filter arguments. Empty
+// fields match every card.
+type cardFilter struct {
+	Brand          string
+	Last4          string
+	ExcludeExpired bool
+}
+
+func NewCardsDataSource() datasource.DataSource {
+	return &cardsDataSource{}
+}
+
+func (d *cardsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_payment_cards"
+}
+
+func (d *cardsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the saved payment cards, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A static identifier for the list",
+			},
+			"brand": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include cards of this brand (e.g., Visa), ignoring case",
+			},
+			"last4": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include cards whose number ends in these 4 digits",
+				Validators:  []validator.String{stringvalidator.LengthBetween(4, 4)},
+			},
+			"exclude_expired": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Set to true to leave out cards that have expired. Cards are valid through the end of their expiration month",
+			},
+			"ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the matching cards",
+			},
+			"cards": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching cards",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the payment card",
+						},
+						"brand": schema.StringAttribute{
+							Computed:    true,
+							Description: "The card brand (e.g., Visa, Mastercard)",
+						},
+						"last4": schema.StringAttribute{
+							Computed:    true,
+							Description: "The last 4 digits of the card number",
+						},
+						"exp_month": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The expiration month (1-12)",
+						},
+						"exp_year": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The expiration year",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *cardsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *cardsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cardsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	filter := cardFilter{
+		Brand:          data.Brand.ValueString(),
+		Last4:          data.Last4.ValueString(),
+		ExcludeExpired: data.ExcludeExpired.ValueBool(),
+	}
+
+	cards, err := d.client.ListCards(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing payment cards", err.Error())
+		return
+	}
+
+	now := time.Now()
+	ids := []string{}
+	models := []cardModel{}
+	for _, card := range cards {
+		if !filter.matches(card, now) {
+			continue
+		}
+
+		ids = append(ids, card.ID)
+		models = append(models, cardModel{
+			ID:       types.StringValue(card.ID),
+			Brand:    types.StringValue(card.Brand),
+			Last4:    types.StringValue(card.Last4),
+			ExpMonth: types.Int64Value(int64(card.ExpMonth)),
+			ExpYear:  types.Int64Value(int64(card.ExpYear)),
+		})
+	}
+
+	data.ID = types.StringValue("payment_cards")
+	data.IDs, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.Cards, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: cardAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matches reports whether a card passes every filter that is set
+func (f cardFilter) matches(card *Card, now time.Time) bool {
+	switch {
+	case f.Brand != "" && !strings.EqualFold(card.Brand, f.Brand):
+		return false
+	case f.Last4 != "" && card.Last4 != f.Last4:
+		return false
+	case f.ExcludeExpired && cardExpired(card, now):
+		return false
+	default:
+		return true
+	}
+}
+
+// cardExpired reports whether a card's expiration month has passed
+func cardExpired(card *Card, now time.Time) bool {
+	year, month := now.Year(), int(now.Month())
+
+	return card.ExpYear < year || (card.ExpYear == year && card.ExpMonth < month)
+}
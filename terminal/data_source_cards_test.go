@@ -0,0 +1,35 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCardFilter(t *testing.T) {
+	now := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		filter   cardFilter
+		card     Card
+		expected bool
+	}{
+		{name: "No filters", card: Card{Brand: "Visa", ExpMonth: 1, ExpYear: 2020}, expected: true},
+		{name: "Brand ignores case", filter: cardFilter{Brand: "visa"}, card: Card{Brand: "Visa"}, expected: true},
+		{name: "Brand mismatch", filter: cardFilter{Brand: "Mastercard"}, card: Card{Brand: "Visa"}, expected: false},
+		{name: "Last4 match", filter: cardFilter{Last4: "4242"}, card: Card{Last4: "4242"}, expected: true},
+		{name: "Last4 mismatch", filter: cardFilter{Last4: "4444"}, card: Card{Last4: "4242"}, expected: false},
+		{name: "Expires this month", filter: cardFilter{ExcludeExpired: true}, card: Card{ExpMonth: 3, ExpYear: 2025}, expected: true},
+		{name: "Expired last month", filter: cardFilter{ExcludeExpired: true}, card: Card{ExpMonth: 2, ExpYear: 2025}, expected: false},
+		{name: "Expired last year", filter: cardFilter{ExcludeExpired: true}, card: Card{ExpMonth: 12, ExpYear: 2024}, expected: false},
+		{name: "Expires next year", filter: cardFilter{ExcludeExpired: true}, card: Card{ExpMonth: 1, ExpYear: 2026}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.matches(&tc.card, now); got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
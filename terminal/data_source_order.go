@@ -2,147 +2,200 @@ package terminal
 
 import (
 	"context"
-	"fmt"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func dataSourceOrder() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataSourceOrderRead,
-		Schema: map[string]*schema.Schema{
-			"order_id": {
-				Type:        schema.TypeString,
+var _ datasource.DataSourceWithConfigure = &orderDataSource{}
+
+// orderDataSource looks up an existing order by ID
+type orderDataSource struct {
+	client *SDKClient
+}
+
+type orderDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	OrderID   types.String   `tfsdk:"order_id"`
+	AddressID types.String   `tfsdk:"address_id"`
+	CardID    types.String   `tfsdk:"card_id"`
+	Variants  types.Map      `tfsdk:"variants"`
+	Status    types.String   `tfsdk:"status"`
+	Total     types.Float64  `tfsdk:"total"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+	Items     types.List     `tfsdk:"items"`
+	Address   types.Object   `tfsdk:"address"`
+	Tracking  types.Object   `tfsdk:"tracking"`
+	Amount    types.Object   `tfsdk:"amount"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewOrderDataSource() datasource.DataSource {
+	return &orderDataSource{}
+}
+
+func (d *orderDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_coffee_order"
+}
+
+func (d *orderDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing coffee order",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the order",
+			},
+			"order_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The ID of the order to retrieve",
 			},
-			"address_id": {
-				Type:        schema.TypeString,
+			"address_id": schema.StringAttribute{
 				Computed:    true,
-				Description: "The ID of the shipping address",
+				Description: "The ID of the saved address matching the order's shipping details, if any",
 			},
-			"card_id": {
-				Type:        schema.TypeString,
+			"card_id": schema.StringAttribute{
 				Computed:    true,
-				Description: "The ID of the payment card",
+				Description: "The ID of the payment card. Orders don't record their card, so this is only set when the account has exactly one saved card",
 			},
-			"variants": {
-				Type:        schema.TypeMap,
+			"variants": schema.MapAttribute{
 				Computed:    true,
+				ElementType: types.Int64Type,
 				Description: "Map of product variant IDs to quantities",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
-			"status": {
-				Type:        schema.TypeString,
+			"status": schema.StringAttribute{
 				Computed:    true,
-				Description: "The status of the order",
+				Description: "The status of the order: placed, or shipped once it has a tracking number or URL. delivered is best-effort, since it relies on a carrier status the API may not send, so a delivered order can stay shipped",
 			},
-			"total": {
-				Type:        schema.TypeFloat,
+			"total": schema.Float64Attribute{
 				Computed:    true,
 				Description: "The total amount of the order",
 			},
-			"created_at": {
-				Type:        schema.TypeString,
+			"created_at": schema.StringAttribute{
 				Computed:    true,
-				Description: "Timestamp when the order was created",
+				Description: "Timestamp when the order was created, from the API's created field. The Terminal SDK doesn't model it, so it is null if the API doesn't send it",
 			},
-			"items": {
-				Type:        schema.TypeList,
+			"items": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "The items in the order",
-				Elem: &schema.Schema{
-					Type: schema.TypeMap,
-					Elem: &schema.Schema{
-						Type: schema.TypeString,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the line item",
+						},
+						"amount": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The price of the line item in cents",
+						},
+						"quantity": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of units ordered",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The description of the line item",
+						},
+						"product_variant_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the product variant ordered",
+						},
 					},
 				},
 			},
-			"address": {
-				Type:        schema.TypeMap,
+			"address": schema.SingleNestedAttribute{
 				Computed:    true,
 				Description: "The shipping address details",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				Attributes: map[string]schema.Attribute{
+					"name":     schema.StringAttribute{Computed: true, Description: "The name of the recipient"},
+					"street1":  schema.StringAttribute{Computed: true, Description: "The first line of the street address"},
+					"street2":  schema.StringAttribute{Computed: true, Description: "The second line of the street address"},
+					"city":     schema.StringAttribute{Computed: true, Description: "The city name"},
+					"province": schema.StringAttribute{Computed: true, Description: "The state or province"},
+					"zip":      schema.StringAttribute{Computed: true, Description: "The zip or postal code"},
+					"country":  schema.StringAttribute{Computed: true, Description: "The country code (e.g., US)"},
+					"phone":    schema.StringAttribute{Computed: true, Description: "The phone number of the recipient"},
 				},
 			},
-			"card": {
-				Type:        schema.TypeMap,
+			"tracking": schema.SingleNestedAttribute{
 				Computed:    true,
-				Description: "The payment card details (masked)",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				Description: "The carrier tracking details. The number and URL are empty until the order ships",
+				Attributes: map[string]schema.Attribute{
+					"number":  schema.StringAttribute{Computed: true, Description: "The tracking number"},
+					"service": schema.StringAttribute{Computed: true, Description: "The shipping service (e.g., USPS)"},
+					"url":     schema.StringAttribute{Computed: true, Description: "The tracking URL"},
+				},
+			},
+			"amount": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The breakdown of the order's charge",
+				Attributes: map[string]schema.Attribute{
+					"subtotal": schema.Int64Attribute{Computed: true, Description: "The price of the items in cents"},
+					"shipping": schema.Int64Attribute{Computed: true, Description: "The shipping charge in cents"},
 				},
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Read: schema.DefaultTimeout(5 * time.Minute),
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
-func dataSourceOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
-
-	var diags diag.Diagnostics
+func (d *orderDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
-	orderID := d.Get("order_id").(string)
+func (d *orderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data orderDataSourceModel
 
-	order, err := client.GetOrder(ctx, orderID)
-	if err != nil {
-		return diag.FromErr(err)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(order.ID)
-	d.Set("address_id", order.AddressID)
-	d.Set("card_id", order.CardID)
-	
-	// For variants, convert int values to strings
-	if order.Variants != nil {
-		variantsForSchema := make(map[string]string)
-		for k, v := range order.Variants {
-			variantsForSchema[k] = fmt.Sprintf("%d", v)
-		}
-		d.Set("variants", variantsForSchema)
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	
-	d.Set("status", order.Status)
-	d.Set("total", order.Total)
-	d.Set("created_at", order.CreatedAt)
-	
-	// Convert items to a format compatible with TypeList of TypeMap
-	if order.Items != nil {
-		itemsForSchema := make([]map[string]string, len(order.Items))
-		for i, item := range order.Items {
-			itemMap := make(map[string]string)
-			for k, v := range item {
-				itemMap[k] = fmt.Sprintf("%v", v)
-			}
-			itemsForSchema[i] = itemMap
-		}
-		d.Set("items", itemsForSchema)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	order, err := d.client.GetOrder(ctx, data.OrderID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading order", err.Error())
+		return
 	}
 
-	// Convert address and card to a format compatible with TypeMap
-	if order.Address != nil {
-		addressMap := make(map[string]string)
-		for k, v := range order.Address {
-			addressMap[k] = fmt.Sprintf("%v", v)
-		}
-		d.Set("address", addressMap)
+	addressID, cardID, err := inferOrderSources(ctx, d.client, order)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading order", err.Error())
+		return
 	}
-	
-	if order.Card != nil {
-		cardMap := make(map[string]string)
-		for k, v := range order.Card {
-			cardMap[k] = fmt.Sprintf("%v", v)
-		}
-		d.Set("card", cardMap)
+
+	data.ID = types.StringValue(order.ID)
+	data.AddressID = optionalString(addressID)
+	data.CardID = optionalString(cardID)
+	data.Status = types.StringValue(order.Status)
+	data.Total = types.Float64Value(order.Total)
+	data.CreatedAt = optionalString(order.CreatedAt)
+
+	data.Variants, diags = flattenOrderVariants(ctx, order.Variants)
+	resp.Diagnostics.Append(diags...)
+	data.Items, diags = flattenOrderItems(ctx, order.Items)
+	resp.Diagnostics.Append(diags...)
+	data.Address, diags = flattenOrderAddress(ctx, order.Shipping)
+	resp.Diagnostics.Append(diags...)
+	data.Tracking, diags = flattenOrderTracking(ctx, order.Tracking)
+	resp.Diagnostics.Append(diags...)
+	data.Amount, diags = flattenOrderAmount(ctx, order.Amount)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diags
-}
\ No newline at end of file
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
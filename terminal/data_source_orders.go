@@ -0,0 +1,288 @@
+package terminal
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &ordersDataSource{}
+
+// ordersDataSource lists the past orders matching optional filters
+type ordersDataSource struct {
+	client *SDKClient
+}
+
+type ordersDataSourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	CreatedAfter  types.String   `tfsdk:"created_after"`
+	CreatedBefore types.String   `tfsdk:"created_before"`
+	VariantID     types.String   `tfsdk:"variant_id"`
+	Status        types.String   `tfsdk:"status"`
+	IDs           types.List     `tfsdk:"ids"`
+	Orders        types.List     `tfsdk:"orders"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// orderSummaryModel is an order as exposed by the orders data source
+type orderSummaryModel struct {
+	ID        types.String  `tfsdk:"id"`
+	Status    types.String  `tfsdk:"status"`
+	Total     types.Float64 `tfsdk:"total"`
+	CreatedAt types.String  `tfsdk:"created_at"`
+	Variants  types.Map     `tfsdk:"variants"`
+	Address   types.Object  `tfsdk:"address"`
+	Tracking  types.Object  `tfsdk:"tracking"`
+	Amount    types.Object  `tfsdk:"amount"`
+}
+
+var orderSummaryAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"status":     types.StringType,
+	"total":      types.Float64Type,
+	"created_at": types.StringType,
+	"variants":   types.MapType{ElemType: types.Int64Type},
+	"address":    types.ObjectType{AttrTypes: orderAddressAttrTypes},
+	"tracking":   types.ObjectType{AttrTypes: orderTrackingAttrTypes},
+	"amount":     types.ObjectType{AttrTypes: orderAmountAttrTypes},
+}
+
+// orderFilter selects orders by the data source's filter arguments. Zero
+// fields match every order.
+type orderFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	VariantID     string
+	Status        string
+}
+
+func NewOrdersDataSource() datasource.DataSource {
+	return &ordersDataSource{}
+}
+
+func (d *ordersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_coffee_orders"
+}
+
+func (d *ordersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the past coffee orders, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A static identifier for the list",
+			},
+			"created_after": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include orders created at or after this RFC 3339 timestamp. Orders without created_at are left out",
+				Validators:  []validator.String{validTimestamp()},
+			},
+			"created_before": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include orders created before this RFC 3339 timestamp. Orders without created_at are left out",
+				Validators:  []validator.String{validTimestamp()},
+			},
+			"variant_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include orders containing this product variant",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include orders with this status: placed, shipped or delivered",
+				Validators:  []validator.String{stringvalidator.OneOf(orderStatuses...)},
+			},
+			"ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the matching orders",
+			},
+			"orders": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching orders",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the order",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the order: placed, or shipped once it has a tracking number or URL. delivered is best-effort, since it relies on a carrier status the API may not send, so a delivered order can stay shipped",
+						},
+						"total": schema.Float64Attribute{
+							Computed:    true,
+							Description: "The total amount of the order",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Timestamp when the order was created, from the API's created field. The Terminal SDK doesn't model it, so it is null if the API doesn't send it",
+						},
+						"variants": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.Int64Type,
+							Description: "Map of product variant IDs to quantities",
+						},
+						"address": schema.SingleNestedAttribute{
+							Computed:    true,
+							Description: "The shipping address details",
+							Attributes: map[string]schema.Attribute{
+								"name":     schema.StringAttribute{Computed: true, Description: "The name of the recipient"},
+								"street1":  schema.StringAttribute{Computed: true, Description: "The first line of the street address"},
+								"street2":  schema.StringAttribute{Computed: true, Description: "The second line of the street address"},
+								"city":     schema.StringAttribute{Computed: true, Description: "The city name"},
+								"province": schema.StringAttribute{Computed: true, Description: "The state or province"},
+								"zip":      schema.StringAttribute{Computed: true, Description: "The zip or postal code"},
+								"country":  schema.StringAttribute{Computed: true, Description: "The country code (e.g., US)"},
+								"phone":    schema.StringAttribute{Computed: true, Description: "The phone number of the recipient"},
+							},
+						},
+						"tracking": schema.SingleNestedAttribute{
+							Computed:    true,
+							Description: "The carrier tracking details",
+							Attributes: map[string]schema.Attribute{
+								"number":  schema.StringAttribute{Computed: true, Description: "The tracking number"},
+								"service": schema.StringAttribute{Computed: true, Description: "The shipping service (e.g., USPS)"},
+								"url":     schema.StringAttribute{Computed: true, Description: "The tracking URL"},
+							},
+						},
+						"amount": schema.SingleNestedAttribute{
+							Computed:    true,
+							Description: "The breakdown of the order's charge",
+							Attributes: map[string]schema.Attribute{
+								"subtotal": schema.Int64Attribute{Computed: true, Description: "The price of the items in cents"},
+								"shipping": schema.Int64Attribute{Computed: true, Description: "The shipping charge in cents"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *ordersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *ordersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ordersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The timestamps were checked by their validators
+	filter := orderFilter{
+		VariantID: data.VariantID.ValueString(),
+		Status:    data.Status.ValueString(),
+	}
+	if v := data.CreatedAfter.ValueString(); v != "" {
+		filter.CreatedAfter, _ = time.Parse(time.RFC3339, v)
+	}
+	if v := data.CreatedBefore.ValueString(); v != "" {
+		filter.CreatedBefore, _ = time.Parse(time.RFC3339, v)
+	}
+
+	orders, err := d.client.ListOrders(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing orders", err.Error())
+		return
+	}
+
+	ids := []string{}
+	models := []orderSummaryModel{}
+	for _, order := range orders {
+		if !filter.matches(order) {
+			continue
+		}
+
+		model, diags := flattenOrderSummary(ctx, order)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ids = append(ids, order.ID)
+		models = append(models, model)
+	}
+
+	data.ID = types.StringValue("coffee_orders")
+	data.IDs, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.Orders, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: orderSummaryAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matches reports whether an order passes every filter that is set. Orders
+// without a readable creation time never match a date range.
+func (f orderFilter) matches(order *Order) bool {
+	if f.VariantID != "" && order.Variants[f.VariantID] == 0 {
+		return false
+	}
+	if f.Status != "" && order.Status != f.Status {
+		return false
+	}
+	if f.CreatedAfter.IsZero() && f.CreatedBefore.IsZero() {
+		return true
+	}
+
+	created, err := time.Parse(time.RFC3339, order.CreatedAt)
+	switch {
+	case err != nil:
+		return false
+	case !f.CreatedAfter.IsZero() && created.Before(f.CreatedAfter):
+		return false
+	case !f.CreatedBefore.IsZero() && !created.Before(f.CreatedBefore):
+		return false
+	default:
+		return true
+	}
+}
+
+// flattenOrderSummary converts an order into an element of the orders list
+func flattenOrderSummary(ctx context.Context, order *Order) (orderSummaryModel, diag.Diagnostics) {
+	var diags, d diag.Diagnostics
+
+	model := orderSummaryModel{
+		ID:        types.StringValue(order.ID),
+		Status:    types.StringValue(order.Status),
+		Total:     types.Float64Value(order.Total),
+		CreatedAt: optionalString(order.CreatedAt),
+	}
+
+	model.Variants, d = flattenOrderVariants(ctx, order.Variants)
+	diags.Append(d...)
+	model.Address, d = flattenOrderAddress(ctx, order.Shipping)
+	diags.Append(d...)
+	model.Tracking, d = flattenOrderTracking(ctx, order.Tracking)
+	diags.Append(d...)
+	model.Amount, d = flattenOrderAmount(ctx, order.Amount)
+	diags.Append(d...)
+
+	return model, diags
+}
@@ -0,0 +1,44 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrderFilter(t *testing.T) {
+	march := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	order := &Order{
+		Variants:  map[string]int{"var_1": 2},
+		Status:    OrderStatusShipped,
+		CreatedAt: "2025-03-01T00:00:00Z",
+	}
+
+	testCases := []struct {
+		name     string
+		filter   orderFilter
+		order    *Order
+		expected bool
+	}{
+		{name: "No filters", order: order, expected: true},
+		{name: "Variant match", filter: orderFilter{VariantID: "var_1"}, order: order, expected: true},
+		{name: "Variant mismatch", filter: orderFilter{VariantID: "var_2"}, order: order, expected: false},
+		{name: "Status match", filter: orderFilter{Status: OrderStatusShipped}, order: order, expected: true},
+		{name: "Status mismatch", filter: orderFilter{Status: OrderStatusDelivered}, order: order, expected: false},
+		{name: "Created after is inclusive", filter: orderFilter{CreatedAfter: march}, order: order, expected: true},
+		{name: "Created before is exclusive", filter: orderFilter{CreatedBefore: march}, order: order, expected: false},
+		{name: "Within range", filter: orderFilter{CreatedAfter: march, CreatedBefore: april}, order: order, expected: true},
+		{name: "After range", filter: orderFilter{CreatedAfter: april}, order: order, expected: false},
+		{name: "Unknown creation time with range", filter: orderFilter{CreatedAfter: march}, order: &Order{}, expected: false},
+		{name: "Unknown creation time without range", order: &Order{}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.matches(tc.order); got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
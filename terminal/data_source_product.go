@@ -0,0 +1,182 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &productDataSource{}
+
+// productDataSource looks up a single product by ID, name or name regex
+type productDataSource struct {
+	client *SDKClient
+}
+
+type productDataSourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	ProductID    types.String   `tfsdk:"product_id"`
+	Name         types.String   `tfsdk:"name"`
+	NameRegex    types.String   `tfsdk:"name_regex"`
+	Description  types.String   `tfsdk:"description"`
+	Subscription types.String   `tfsdk:"subscription"`
+	Tags         types.Object   `tfsdk:"tags"`
+	Variants     types.List     `tfsdk:"variants"`
+	VariantIDs   types.Map      `tfsdk:"variant_ids"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewProductDataSource() datasource.DataSource {
+	return &productDataSource{}
+}
+
+func (d *productDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product"
+}
+
+func (d *productDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	exactlyOneLookup := stringvalidator.ExactlyOneOf(
+		path.MatchRoot("product_id"),
+		path.MatchRoot("name"),
+		path.MatchRoot("name_regex"),
+	)
+
+	attributes := productAttributes()
+	attributes["product_id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The ID of the product to retrieve",
+		Validators:  []validator.String{exactlyOneLookup},
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The exact name of the product to retrieve",
+		Validators:  []validator.String{exactlyOneLookup},
+	}
+	attributes["name_regex"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "A regular expression that must match exactly one product name",
+		Validators:  []validator.String{exactlyOneLookup, validRegexp()},
+	}
+	attributes["variant_ids"] = schema.MapAttribute{
+		Computed:    true,
+		ElementType: types.StringType,
+		Description: "Map of variant names to variant IDs (e.g., variant_ids[\"12oz\"])",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single product in the Terminal catalog",
+		Attributes:  attributes,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *productDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *productDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data productDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var product *Product
+	if !data.ProductID.IsNull() {
+		p, err := d.client.GetProduct(ctx, data.ProductID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading product", err.Error())
+			return
+		}
+		product = p
+	} else {
+		products, err := d.client.ListProducts(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing products", err.Error())
+			return
+		}
+
+		p, err := findProduct(products, data.Name.ValueString(), data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error finding product", err.Error())
+			return
+		}
+		product = p
+	}
+
+	variantIDs := make(map[string]string)
+	for _, v := range product.Variants {
+		variantIDs[v.Name] = v.ID
+	}
+
+	flattened := flattenProduct(product)
+
+	data.ID = flattened.ID
+	data.ProductID = flattened.ID
+	data.Name = flattened.Name
+	data.Description = flattened.Description
+	data.Subscription = flattened.Subscription
+
+	data.Tags, diags = types.ObjectValueFrom(ctx, productTagsAttrTypes, flattened.Tags)
+	resp.Diagnostics.Append(diags...)
+	data.Variants, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: productVariantAttrTypes}, flattened.Variants)
+	resp.Diagnostics.Append(diags...)
+	data.VariantIDs, diags = types.MapValueFrom(ctx, types.StringType, variantIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findProduct returns the single product matching either the exact name or the
+// name regex
+func findProduct(products []*Product, name, nameRegex string) (*Product, error) {
+	var re *regexp.Regexp
+	if nameRegex != "" {
+		var err error
+		re, err = regexp.Compile(nameRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name_regex: %v", err)
+		}
+	}
+
+	var matches []*Product
+	for _, p := range products {
+		if (re != nil && re.MatchString(p.Name)) || (re == nil && p.Name == name) {
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no product found matching the given criteria")
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d products match the given criteria, please refine your search", len(matches))
+	}
+}
@@ -0,0 +1,46 @@
+package terminal
+
+import (
+	"testing"
+)
+
+func TestFindProduct(t *testing.T) {
+	products := []*Product{
+		{ID: "prd_1", Name: "segfault"},
+		{ID: "prd_2", Name: "dark mode"},
+		{ID: "prd_3", Name: "dark mode decaf"},
+	}
+
+	testCases := []struct {
+		name       string
+		exactName  string
+		nameRegex  string
+		expectedID string
+		expectErr  bool
+	}{
+		{name: "Exact name", exactName: "segfault", expectedID: "prd_1"},
+		{name: "Exact name ignores prefixes", exactName: "dark mode", expectedID: "prd_2"},
+		{name: "Regex with single match", nameRegex: "decaf$", expectedID: "prd_3"},
+		{name: "Regex with multiple matches", nameRegex: "^dark", expectErr: true},
+		{name: "No match", exactName: "flow", expectErr: true},
+		{name: "Invalid regex", nameRegex: "(", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			product, err := findProduct(products, tc.exactName, tc.nameRegex)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got product %s", product.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if product.ID != tc.expectedID {
+				t.Errorf("Expected product %s, got %s", tc.expectedID, product.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package terminal
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &productsDataSource{}
+
+// productsDataSource lists the Terminal product catalog
+type productsDataSource struct {
+	client *SDKClient
+}
+
+type productsDataSourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Products types.List     `tfsdk:"products"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// productModel is a product as exposed by the product data sources
+type productModel struct {
+	ID           types.String          `tfsdk:"id"`
+	Name         types.String          `tfsdk:"name"`
+	Description  types.String          `tfsdk:"description"`
+	Subscription types.String          `tfsdk:"subscription"`
+	Tags         productTagsModel      `tfsdk:"tags"`
+	Variants     []productVariantModel `tfsdk:"variants"`
+}
+
+type productTagsModel struct {
+	App      types.String `tfsdk:"app"`
+	Color    types.String `tfsdk:"color"`
+	Featured types.Bool   `tfsdk:"featured"`
+	MarketEU types.Bool   `tfsdk:"market_eu"`
+	MarketNA types.Bool   `tfsdk:"market_na"`
+}
+
+type productVariantModel struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Price types.Int64  `tfsdk:"price"`
+}
+
+var productTagsAttrTypes = map[string]attr.Type{
+	"app":       types.StringType,
+	"color":     types.StringType,
+	"featured":  types.BoolType,
+	"market_eu": types.BoolType,
+	"market_na": types.BoolType,
+}
+
+var productVariantAttrTypes = map[string]attr.Type{
+	"id":    types.StringType,
+	"name":  types.StringType,
+	"price": types.Int64Type,
+}
+
+var productAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"description":  types.StringType,
+	"subscription": types.StringType,
+	"tags":         types.ObjectType{AttrTypes: productTagsAttrTypes},
+	"variants":     types.ListType{ElemType: types.ObjectType{AttrTypes: productVariantAttrTypes}},
+}
+
+func NewProductsDataSource() datasource.DataSource {
+	return &productsDataSource{}
+}
+
+func (d *productsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_products"
+}
+
+func (d *productsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Terminal product catalog",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A static identifier for the catalog",
+			},
+			"products": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Every product in the Terminal catalog",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: productAttributes(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+// productAttributes returns the computed attributes shared by the product data sources
+func productAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the product",
+		},
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the product",
+		},
+		"description": schema.StringAttribute{
+			Computed:    true,
+			Description: "The description of the product",
+		},
+		"subscription": schema.StringAttribute{
+			Computed:    true,
+			Description: "Whether the product can (\"allowed\") or must (\"required\") be subscribed to",
+		},
+		"tags": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The display and market tags of the product",
+			Attributes: map[string]schema.Attribute{
+				"app": schema.StringAttribute{
+					Computed:    true,
+					Description: "The app the product belongs to",
+				},
+				"color": schema.StringAttribute{
+					Computed:    true,
+					Description: "The display color of the product",
+				},
+				"featured": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the product is featured",
+				},
+				"market_eu": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the product is sold in the EU",
+				},
+				"market_na": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the product is sold in North America",
+				},
+			},
+		},
+		"variants": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The purchasable variants of the product",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:    true,
+						Description: "The ID of the variant, as used in terminal_coffee_order",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the variant (e.g., 12oz)",
+					},
+					"price": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The price of the variant in cents (USD)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenProduct converts a Product into the model used by productAttributes
+func flattenProduct(product *Product) productModel {
+	variants := make([]productVariantModel, len(product.Variants))
+	for i, v := range product.Variants {
+		variants[i] = productVariantModel{
+			ID:    types.StringValue(v.ID),
+			Name:  types.StringValue(v.Name),
+			Price: types.Int64Value(int64(v.Price)),
+		}
+	}
+
+	return productModel{
+		ID:           types.StringValue(product.ID),
+		Name:         types.StringValue(product.Name),
+		Description:  types.StringValue(product.Description),
+		Subscription: types.StringValue(product.Subscription),
+		Tags: productTagsModel{
+			App:      types.StringValue(product.Tags.App),
+			Color:    types.StringValue(product.Tags.Color),
+			Featured: types.BoolValue(product.Tags.Featured),
+			MarketEU: types.BoolValue(product.Tags.MarketEU),
+			MarketNA: types.BoolValue(product.Tags.MarketNA),
+		},
+		Variants: variants,
+	}
+}
+
+func (d *productsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *productsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data productsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	products, err := d.client.ListProducts(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing products", err.Error())
+		return
+	}
+
+	models := make([]productModel, len(products))
+	for i, product := range products {
+		models[i] = flattenProduct(product)
+	}
+
+	data.ID = types.StringValue("products")
+	data.Products, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: productAttrTypes}, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,89 @@
+package terminal
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &profileDataSource{}
+
+// profileDataSource reads the profile of the account the API token belongs to
+type profileDataSource struct {
+	client *SDKClient
+}
+
+type profileDataSourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Email    types.String   `tfsdk:"email"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewProfileDataSource() datasource.DataSource {
+	return &profileDataSource{}
+}
+
+func (d *profileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_profile"
+}
+
+func (d *profileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the profile of the account the API token belongs to",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the user",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the user",
+			},
+			"email": schema.StringAttribute{
+				Computed:    true,
+				Description: "The email address of the user",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *profileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *profileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data profileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	profile, err := d.client.GetProfile(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading profile", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(profile.ID)
+	data.Name = optionalString(profile.Name)
+	data.Email = optionalString(profile.Email)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
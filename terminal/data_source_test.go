@@ -0,0 +1,194 @@
+package terminal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceAddress_basic(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAddressConfig(providerConfig, false) + `
+data "terminal_address" "test" {
+  address_id = terminal_address.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.terminal_address.test", "name", "terminal_address.test", "name"),
+					resource.TestCheckResourceAttrPair("data.terminal_address.test", "street1", "terminal_address.test", "street1"),
+					resource.TestCheckResourceAttrPair("data.terminal_address.test", "street2", "terminal_address.test", "street2"),
+					resource.TestCheckResourceAttrPair("data.terminal_address.test", "city", "terminal_address.test", "city"),
+					resource.TestCheckResourceAttrPair("data.terminal_address.test", "state", "terminal_address.test", "state"),
+					resource.TestCheckResourceAttrPair("data.terminal_address.test", "zip", "terminal_address.test", "zip"),
+					resource.TestCheckResourceAttrPair("data.terminal_address.test", "country", "terminal_address.test", "country"),
+				),
+			},
+			{
+				// Unset optional fields are null rather than empty strings
+				Config: testAccAddressLocationConfig(providerConfig, "", "SW1A 1AA", "GB") + `
+data "terminal_address" "test" {
+  address_id = terminal_address.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.terminal_address.test", "street2"),
+					resource.TestCheckNoResourceAttr("data.terminal_address.test", "state"),
+					resource.TestCheckNoResourceAttr("data.terminal_address.test", "phone"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceCard_basic(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCardConfig(providerConfig) + `
+data "terminal_payment_card" "test" {
+  card_id = terminal_payment_card.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.terminal_payment_card.test", "brand", "terminal_payment_card.test", "brand"),
+					resource.TestCheckResourceAttrPair("data.terminal_payment_card.test", "last4", "terminal_payment_card.test", "last4"),
+					resource.TestCheckResourceAttrPair("data.terminal_payment_card.test", "exp_month", "terminal_payment_card.test", "exp_month"),
+					resource.TestCheckResourceAttrPair("data.terminal_payment_card.test", "exp_year", "terminal_payment_card.test", "exp_year"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceOrder_basic(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrderConfig(providerConfig) + `
+data "terminal_coffee_order" "test" {
+  order_id = terminal_coffee_order.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.terminal_coffee_order.test", "status", "terminal_coffee_order.test", "status"),
+					resource.TestCheckResourceAttrPair("data.terminal_coffee_order.test", "total", "terminal_coffee_order.test", "total"),
+					resource.TestCheckResourceAttr("data.terminal_coffee_order.test", "variants."+testAccVariantID(), "1"),
+					resource.TestCheckResourceAttr("data.terminal_coffee_order.test", "status", "placed"),
+					resource.TestCheckResourceAttrSet("data.terminal_coffee_order.test", "created_at"),
+					resource.TestCheckResourceAttrPair("data.terminal_coffee_order.test", "address_id", "terminal_address.test", "id"),
+					resource.TestCheckResourceAttrPair("data.terminal_coffee_order.test", "address.street1", "terminal_address.test", "street1"),
+					resource.TestCheckResourceAttrSet("data.terminal_coffee_order.test", "amount.subtotal"),
+					resource.TestCheckResourceAttrSet("data.terminal_coffee_order.test", "tracking.service"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceProduct_basic(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "terminal_products" "all" {}
+
+data "terminal_product" "segfault" {
+  name = "segfault"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.terminal_products.all", "products.0.id"),
+					resource.TestCheckResourceAttrSet("data.terminal_product.segfault", "product_id"),
+					resource.TestCheckResourceAttrSet("data.terminal_product.segfault", "variant_ids.12oz"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceProfile_basic(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileConfig(providerConfig, "Coffee Ops", "ops@example.com") + `
+data "terminal_profile" "test" {
+  depends_on = [terminal_profile.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.terminal_profile.test", "id", "terminal_profile.test", "id"),
+					resource.TestCheckResourceAttr("data.terminal_profile.test", "name", "Coffee Ops"),
+					resource.TestCheckResourceAttr("data.terminal_profile.test", "email", "ops@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceLists_filters(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrderConfig(providerConfig) + fmt.Sprintf(`
+data "terminal_addresses" "test" {
+  name    = terminal_address.test.name
+  country = "us"
+}
+
+data "terminal_addresses" "none" {
+  zip        = "00000"
+  depends_on = [terminal_address.test]
+}
+
+data "terminal_payment_cards" "test" {
+  brand           = "visa"
+  exclude_expired = true
+  depends_on      = [terminal_payment_card.test]
+}
+
+data "terminal_coffee_orders" "test" {
+  variant_id    = %[1]q
+  created_after = "2000-01-01T00:00:00Z"
+  depends_on    = [terminal_coffee_order.test]
+}
+
+data "terminal_coffee_orders" "delivered" {
+  status     = "delivered"
+  depends_on = [terminal_coffee_order.test]
+}
+`, testAccVariantID()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttrPair("data.terminal_addresses.test", "ids.*", "terminal_address.test", "id"),
+					resource.TestCheckResourceAttrPair("data.terminal_addresses.test", "addresses.0.street1", "terminal_address.test", "street1"),
+					resource.TestCheckResourceAttr("data.terminal_addresses.none", "ids.#", "0"),
+					resource.TestCheckTypeSetElemAttrPair("data.terminal_payment_cards.test", "ids.*", "terminal_payment_card.test", "id"),
+					resource.TestCheckResourceAttr("data.terminal_payment_cards.test", "cards.0.brand", "Visa"),
+					resource.TestCheckTypeSetElemAttrPair("data.terminal_coffee_orders.test", "ids.*", "terminal_coffee_order.test", "id"),
+					resource.TestCheckResourceAttr("data.terminal_coffee_orders.test", "orders.0.variants."+testAccVariantID(), "1"),
+					resource.TestCheckResourceAttr("data.terminal_coffee_orders.delivered", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package terminal
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &cardCollectionEphemeralResource{}
+
+// cardCollectionEphemeralResource returns a one-time URL where a card can be
+// entered at terminal.shop, as an alternative to passing Stripe tokens
+// through Terraform
+type cardCollectionEphemeralResource struct {
+	client *SDKClient
+}
+
+type cardCollectionEphemeralResourceModel struct {
+	URL types.String `tfsdk:"url"`
+}
+
+func NewCardCollectionEphemeralResource() ephemeral.EphemeralResource {
+	return &cardCollectionEphemeralResource{}
+}
+
+func (r *cardCollectionEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_card_collection"
+}
+
+func (r *cardCollectionEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A one-time URL where a payment card can be entered securely at terminal.shop",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The temporary card collection URL. Cards entered there show up in the terminal_payment_cards data source",
+			},
+		},
+	}
+}
+
+func (r *cardCollectionEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *cardCollectionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	url, err := r.client.CollectCard(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating card collection URL", err.Error())
+		return
+	}
+
+	data := cardCollectionEphemeralResourceModel{
+		URL: types.StringValue(url),
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
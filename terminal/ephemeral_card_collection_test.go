@@ -0,0 +1,19 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCardCollectionEphemeralResource(t *testing.T) {
+	attributes, closeResource, _ := testOpenEphemeralResource(t, "terminal_card_collection")
+	defer closeResource()
+
+	var url string
+	if err := attributes["url"].As(&url); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !strings.HasPrefix(url, "https://") {
+		t.Errorf("Expected an https collection URL, got %q", url)
+	}
+}
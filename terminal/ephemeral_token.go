@@ -0,0 +1,109 @@
+package terminal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &tokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &tokenEphemeralResource{}
+)
+
+// tokenPrivateKey is the private data key holding the ID of the token to
+// revoke on close
+const tokenPrivateKey = "token_id"
+
+// tokenEphemeralResource mints a personal access token that only lasts for a
+// single Terraform run, so its value never lands in state
+type tokenEphemeralResource struct {
+	client *SDKClient
+}
+
+type tokenEphemeralResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Token types.String `tfsdk:"token"`
+}
+
+func NewTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &tokenEphemeralResource{}
+}
+
+func (r *tokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_personal_access_token"
+}
+
+func (r *tokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A personal access token for the Terminal API that is revoked at the end of the Terraform run",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the token",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token value",
+			},
+		},
+	}
+}
+
+func (r *tokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *tokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	token, err := r.client.CreateToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating token", err.Error())
+		return
+	}
+
+	// Record the ID first, so the token is revoked even if setting the result
+	// fails
+	id, err := json.Marshal(token.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, tokenPrivateKey, id)...)
+
+	data := tokenEphemeralResourceModel{
+		ID:    types.StringValue(token.ID),
+		Token: types.StringValue(token.Token),
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close revokes the token minted by Open. Tokens that are already gone are
+// ignored.
+func (r *tokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, tokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		resp.Diagnostics.AddError("Error deleting token", err.Error())
+		return
+	}
+
+	if err := r.client.DeleteToken(ctx, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			tflog.Warn(ctx, "Token not found, nothing to revoke", map[string]interface{}{"id": id})
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting token", err.Error())
+	}
+}
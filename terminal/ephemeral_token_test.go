@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestTokenEphemeralResource_revokedOnClose(t *testing.T) {
+	attributes, closeResource, client := testOpenEphemeralResource(t, "terminal_personal_access_token")
+
+	var id, token string
+	if err := attributes["id"].As(&id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := attributes["token"].As(&token); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if token == "" {
+		t.Fatal("Expected a token value")
+	}
+
+	if _, err := client.GetToken(context.Background(), id); err != nil {
+		t.Fatalf("Expected token %s to exist while open: %v", id, err)
+	}
+
+	closeResource()
+
+	if _, err := client.GetToken(context.Background(), id); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected token %s to be revoked on close, got: %v", id, err)
+	}
+
+	// Closing again must not fail now the token is gone
+	closeResource()
+}
+
+func TestAccTokenEphemeralResource_basic(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"terminal": testAccProtoV6ProviderFactories["terminal"],
+			"echo":     echoprovider.NewProviderServer(),
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+ephemeral "terminal_personal_access_token" "test" {}
+
+provider "echo" {
+  data = ephemeral.terminal_personal_access_token.test.id
+}
+
+resource "echo" "test" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data"), knownvalue.StringRegexp(regexp.MustCompile(`^pat_`))),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/terminaldotshop/terminal-sdk-go"
+)
+
+// Error kinds returned by SDKClient methods. Use errors.Is to check for them:
+//
+//	if errors.Is(err, ErrNotFound) { ... }
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+	ErrServer       = errors.New("server error")
+)
+
+// APIError is returned by SDKClient methods when the Terminal API responds with
+// an error status code
+type APIError struct {
+	// Op describes what the client was doing, e.g. "error retrieving address"
+	Op string
+	// StatusCode is the HTTP status code returned by the API
+	StatusCode int
+	// Kind is one of the Err* values above, or nil if the status is unclassified
+	Kind error
+	// Err is the underlying SDK error
+	Err error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Op, e.Err)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error is of the given kind
+func (e *APIError) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
+
+// wrapError converts an SDK error into an APIError when it carries an HTTP
+// status code, otherwise it keeps the plain "<op>: <err>" format
+func wrapError(op string, err error) error {
+	var sdkErr *terminal.Error
+	if !errors.As(err, &sdkErr) {
+		return fmt.Errorf("%s: %v", op, err)
+	}
+
+	return &APIError{
+		Op:         op,
+		StatusCode: sdkErr.StatusCode,
+		Kind:       errorKind(sdkErr.StatusCode),
+		Err:        err,
+	}
+}
+
+// errorKind maps an HTTP status code to one of the Err* kinds
+func errorKind(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusConflict, statusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case statusCode >= http.StatusInternalServerError:
+		return ErrServer
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/terminaldotshop/terminal-sdk-go"
+)
+
+// newSDKError builds an SDK error as returned for a failed API call
+func newSDKError(statusCode int) *terminal.Error {
+	return &terminal.Error{
+		StatusCode: statusCode,
+		Request:    httptest.NewRequest(http.MethodGet, "https://api.terminal.shop/address/shp_123", nil),
+		Response:   &http.Response{StatusCode: statusCode},
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	testCases := []struct {
+		statusCode   int
+		expectedKind error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadRequest, ErrValidation},
+		{http.StatusUnprocessableEntity, ErrValidation},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusBadGateway, ErrServer},
+	}
+
+	kinds := []error{ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrValidation, ErrServer}
+
+	for _, tc := range testCases {
+		t.Run(http.StatusText(tc.statusCode), func(t *testing.T) {
+			err := wrapError("error retrieving address", newSDKError(tc.statusCode))
+
+			for _, kind := range kinds {
+				if got := errors.Is(err, kind); got != (kind == tc.expectedKind) {
+					t.Errorf("errors.Is(err, %v) = %v", kind, got)
+				}
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected an *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tc.statusCode {
+				t.Errorf("Expected status code %d, got %d", tc.statusCode, apiErr.StatusCode)
+			}
+
+			// The original SDK error must remain reachable
+			var sdkErr *terminal.Error
+			if !errors.As(err, &sdkErr) {
+				t.Error("Expected the SDK error to be unwrappable")
+			}
+		})
+	}
+}
+
+func TestWrapErrorWithoutStatus(t *testing.T) {
+	err := wrapError("error creating card", errors.New("connection refused"))
+
+	if err.Error() != "error creating card: connection refused" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrServer) {
+		t.Error("Errors without a status code should not be classified")
+	}
+}
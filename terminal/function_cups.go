@@ -0,0 +1,140 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &cupsFunction{}
+
+// gramsPerCup is the coffee used for one 8 oz cup, at roughly a 1:16 ratio
+const gramsPerCup = 15.0
+
+// bagSizePattern matches a bag size such as "12oz" or "2 lb", optionally
+// followed by more of the variant name
+var bagSizePattern = regexp.MustCompile(`(?i)^\s*(\d+(?:\.\d+)?)\s*(oz|lbs?|kg|g)\b`)
+
+// gramsPerUnit converts the units of bag sizes to grams
+var gramsPerUnit = map[string]float64{
+	"oz":  28.349523125,
+	"lb":  453.59237,
+	"lbs": 453.59237,
+	"g":   1,
+	"kg":  1000,
+}
+
+// cupsFunction estimates how many cups of coffee order items brew
+type cupsFunction struct{}
+
+// cupsProductModel is the part of a product that cups reads. Like
+// order_total, it takes a catalog such as the terminal_products data source's
+// and drops any other attributes.
+type cupsProductModel struct {
+	Variants []cupsVariantModel `tfsdk:"variants"`
+}
+
+// cupsVariantModel is a product variant, whose name gives its bag size
+type cupsVariantModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+var cupsVariantAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+}
+
+var cupsProductAttrTypes = map[string]attr.Type{
+	"variants": types.ListType{ElemType: types.ObjectType{AttrTypes: cupsVariantAttrTypes}},
+}
+
+func NewCupsFunction() function.Function {
+	return &cupsFunction{}
+}
+
+func (f *cupsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cups"
+}
+
+func (f *cupsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Estimates the cups of coffee brewed from order items",
+		Description: fmt.Sprintf("Returns the number of 8 oz cups brewed from the given items, at %g g of coffee per cup. Items are objects with a variant_id and quantity, as for order_total, and products is a catalog such as data.terminal_products.all.products whose variant names give the bag size, such as 12oz or 2lb.", gramsPerCup),
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "items",
+				ElementType: types.ObjectType{AttrTypes: orderLineAttrTypes},
+				Description: "The items to brew, each with a variant_id and quantity",
+			},
+			function.ListParameter{
+				Name:        "products",
+				ElementType: types.ObjectType{AttrTypes: cupsProductAttrTypes},
+				Description: "The product catalog, each product with a list of variants holding an id and a name starting with the bag size",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *cupsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var items []orderLineModel
+	var products []cupsProductModel
+
+	resp.Error = req.Arguments.Get(ctx, &items, &products)
+	if resp.Error != nil {
+		return
+	}
+
+	sizes := make(map[string]string)
+	for _, product := range products {
+		for _, variant := range product.Variants {
+			sizes[variant.ID.ValueString()] = variant.Name.ValueString()
+		}
+	}
+
+	var grams float64
+	for _, item := range items {
+		variantID := item.VariantID.ValueString()
+		size, ok := sizes[variantID]
+		if !ok {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("product variant %q is not in the catalog", variantID))
+			return
+		}
+		if item.Quantity.ValueInt64() < 0 {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("product variant %q has a negative quantity", variantID))
+			return
+		}
+
+		bagGrams, err := parseBagSize(size)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("product variant %q: %s", variantID, err))
+			return
+		}
+		grams += bagGrams * float64(item.Quantity.ValueInt64())
+	}
+
+	resp.Error = resp.Result.Set(ctx, int64(math.Floor(grams/gramsPerCup)))
+}
+
+// parseBagSize returns the weight in grams of a bag size such as "12oz"
+func parseBagSize(size string) (float64, error) {
+	match := bagSizePattern.FindStringSubmatch(size)
+	if match == nil {
+		return 0, fmt.Errorf("%q is not a bag size such as 12oz, 2lb or 250g", size)
+	}
+
+	amount, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a bag size: %w", size, err)
+	}
+
+	return amount * gramsPerUnit[strings.ToLower(match[2])], nil
+}
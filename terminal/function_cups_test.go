@@ -0,0 +1,81 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCupsFunction(t *testing.T) {
+	ctx := context.Background()
+
+	variant := func(id, name string) attr.Value {
+		return types.ObjectValueMust(cupsVariantAttrTypes, map[string]attr.Value{
+			"id":   types.StringValue(id),
+			"name": types.StringValue(name),
+		})
+	}
+	products := types.ListValueMust(types.ObjectType{AttrTypes: cupsProductAttrTypes}, []attr.Value{
+		types.ObjectValueMust(cupsProductAttrTypes, map[string]attr.Value{
+			"variants": types.ListValueMust(types.ObjectType{AttrTypes: cupsVariantAttrTypes}, []attr.Value{
+				variant("var_12oz", "12oz"),
+				variant("var_2lb", "2lb"),
+				variant("var_250g", "250g"),
+				variant("var_beans", "12oz | Whole Beans"),
+				variant("var_kg", "1.5 KG"),
+				variant("var_large", "large"),
+			}),
+		}),
+	})
+	items := func(lines map[string]int64) types.List {
+		values := []attr.Value{}
+		for variantID, quantity := range lines {
+			values = append(values, types.ObjectValueMust(orderLineAttrTypes, map[string]attr.Value{
+				"variant_id": types.StringValue(variantID),
+				"quantity":   types.Int64Value(quantity),
+			}))
+		}
+		return types.ListValueMust(types.ObjectType{AttrTypes: orderLineAttrTypes}, values)
+	}
+
+	testCases := []struct {
+		name      string
+		items     types.List
+		expected  int64
+		expectErr bool
+	}{
+		{name: "No bags", items: items(nil), expected: 0},
+		{name: "One 12oz bag", items: items(map[string]int64{"var_12oz": 1}), expected: 22},
+		{name: "Pounds and grams", items: items(map[string]int64{"var_2lb": 1, "var_250g": 2}), expected: 93},
+		{name: "Size with variant details", items: items(map[string]int64{"var_beans": 1}), expected: 22},
+		{name: "Decimal kilograms", items: items(map[string]int64{"var_kg": 1}), expected: 100},
+		{name: "Unknown size", items: items(map[string]int64{"var_large": 1}), expectErr: true},
+		{name: "Unknown variant", items: items(map[string]int64{"var_5lb": 1}), expectErr: true},
+		{name: "Negative bags", items: items(map[string]int64{"var_12oz": -1}), expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := function.RunResponse{Result: function.NewResultData(types.Int64Unknown())}
+			NewCupsFunction().Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{tc.items, products}),
+			}, &resp)
+
+			if tc.expectErr {
+				if resp.Error == nil {
+					t.Fatalf("Expected an error, got %s", resp.Result.Value())
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().(types.Int64).ValueInt64(); got != tc.expected {
+				t.Errorf("Expected %d cups, got %d", tc.expected, got)
+			}
+		})
+	}
+}
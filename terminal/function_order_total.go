@@ -0,0 +1,108 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &orderTotalFunction{}
+
+// orderTotalFunction prices order items against the product catalog
+type orderTotalFunction struct{}
+
+// catalogProductModel is the part of a product that order_total reads. Extra
+// attributes, such as those of the terminal_products data source, are
+// dropped when Terraform converts the argument.
+type catalogProductModel struct {
+	Variants []catalogVariantModel `tfsdk:"variants"`
+}
+
+type catalogVariantModel struct {
+	ID    types.String `tfsdk:"id"`
+	Price types.Int64  `tfsdk:"price"`
+}
+
+var catalogVariantAttrTypes = map[string]attr.Type{
+	"id":    types.StringType,
+	"price": types.Int64Type,
+}
+
+var catalogProductAttrTypes = map[string]attr.Type{
+	"variants": types.ListType{ElemType: types.ObjectType{AttrTypes: catalogVariantAttrTypes}},
+}
+
+func NewOrderTotalFunction() function.Function {
+	return &orderTotalFunction{}
+}
+
+func (f *orderTotalFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "order_total"
+}
+
+func (f *orderTotalFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Computes the price of order items from the product catalog",
+		Description: "Returns the price in dollars of the given items, before shipping. Items are objects with a variant_id and quantity, such as the item blocks of terminal_coffee_order, and products is a catalog such as data.terminal_products.all.products.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "items",
+				ElementType: types.ObjectType{AttrTypes: orderLineAttrTypes},
+				Description: "The items to price, each with a variant_id and quantity",
+			},
+			function.ListParameter{
+				Name:        "products",
+				ElementType: types.ObjectType{AttrTypes: catalogProductAttrTypes},
+				Description: "The product catalog, each product with a list of variants holding an id and a price in cents",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *orderTotalFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var items []orderLineModel
+	var products []catalogProductModel
+
+	resp.Error = req.Arguments.Get(ctx, &items, &products)
+	if resp.Error != nil {
+		return
+	}
+
+	prices := make(map[string]int64)
+	for _, product := range products {
+		for _, variant := range product.Variants {
+			prices[variant.ID.ValueString()] = variant.Price.ValueInt64()
+		}
+	}
+
+	totalCents, err := orderItemsCents(items, prices)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, float64(totalCents)/100.0)
+}
+
+// orderItemsCents totals the price of order items in cents, given variant
+// prices in cents
+func orderItemsCents(items []orderLineModel, prices map[string]int64) (int64, error) {
+	var total int64
+	for _, item := range items {
+		variantID := item.VariantID.ValueString()
+		price, ok := prices[variantID]
+		if !ok {
+			return 0, fmt.Errorf("product variant %q is not in the catalog", variantID)
+		}
+		if item.Quantity.ValueInt64() < 0 {
+			return 0, fmt.Errorf("product variant %q has a negative quantity", variantID)
+		}
+		total += price * item.Quantity.ValueInt64()
+	}
+
+	return total, nil
+}
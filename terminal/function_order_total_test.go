@@ -0,0 +1,100 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOrderTotalFunction(t *testing.T) {
+	ctx := context.Background()
+
+	variant := func(id string, price int64) attr.Value {
+		return types.ObjectValueMust(catalogVariantAttrTypes, map[string]attr.Value{
+			"id":    types.StringValue(id),
+			"price": types.Int64Value(price),
+		})
+	}
+	products := types.ListValueMust(types.ObjectType{AttrTypes: catalogProductAttrTypes}, []attr.Value{
+		types.ObjectValueMust(catalogProductAttrTypes, map[string]attr.Value{
+			"variants": types.ListValueMust(types.ObjectType{AttrTypes: catalogVariantAttrTypes}, []attr.Value{
+				variant("var_12oz", 2200),
+				variant("var_2lb", 6400),
+			}),
+		}),
+	})
+	items := func(lines map[string]int64) types.List {
+		values := []attr.Value{}
+		for variantID, quantity := range lines {
+			values = append(values, types.ObjectValueMust(orderLineAttrTypes, map[string]attr.Value{
+				"variant_id": types.StringValue(variantID),
+				"quantity":   types.Int64Value(quantity),
+			}))
+		}
+		return types.ListValueMust(types.ObjectType{AttrTypes: orderLineAttrTypes}, values)
+	}
+
+	testCases := []struct {
+		name      string
+		items     types.List
+		expected  float64
+		expectErr bool
+	}{
+		{name: "No items", items: items(nil), expected: 0},
+		{name: "Single item", items: items(map[string]int64{"var_12oz": 1}), expected: 22},
+		{name: "Several items", items: items(map[string]int64{"var_12oz": 2, "var_2lb": 1}), expected: 108},
+		{name: "Unknown variant", items: items(map[string]int64{"var_5lb": 1}), expectErr: true},
+		{name: "Negative quantity", items: items(map[string]int64{"var_12oz": -1}), expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := function.RunResponse{Result: function.NewResultData(types.Float64Unknown())}
+			NewOrderTotalFunction().Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{tc.items, products}),
+			}, &resp)
+
+			if tc.expectErr {
+				if resp.Error == nil {
+					t.Fatalf("Expected an error, got %s", resp.Result.Value())
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().(types.Float64).ValueFloat64(); got != tc.expected {
+				t.Errorf("Expected %g, got %g", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccOrderTotalFunction_products(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "terminal_products" "all" {}
+
+output "total" {
+  value = provider::terminal::order_total([{ variant_id = %q, quantity = 2 }], data.terminal_products.all.products)
+}
+`, testAccVariantID()),
+				Check: resource.TestCheckOutput("total", "44"),
+			},
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &parseIDFunction{}
+
+// idKinds maps Terminal ID prefixes to the kind of object they identify.
+// Kinds match the resource type names where one exists.
+var idKinds = map[string]string{
+	"shp": "address",
+	"crd": "payment_card",
+	"ord": "coffee_order",
+	"var": "product_variant",
+	"prd": "product",
+	"sub": "subscription",
+}
+
+// parseIDFunction reports what kind of object a Terminal ID refers to
+type parseIDFunction struct{}
+
+func NewParseIDFunction() function.Function {
+	return &parseIDFunction{}
+}
+
+func (f *parseIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+func (f *parseIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Returns the kind of object a Terminal ID refers to",
+		Description: "Returns the kind of object identified by a Terminal ID, based on its prefix: address (shp_), payment_card (crd_), coffee_order (ord_), product_variant (var_), product (prd_) or subscription (sub_).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The Terminal ID to parse, such as shp_XXXXXXXXXXXXXXXXXXXXXXXXX",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *parseIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	kind, err := idKind(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, kind)
+}
+
+// idKind returns the kind of object identified by a Terminal ID
+func idKind(id string) (string, error) {
+	prefix, rest, ok := strings.Cut(id, "_")
+	if !ok || rest == "" {
+		return "", fmt.Errorf("%q is not a Terminal ID, which looks like prefix_XXXXXXXXXXXXXXXXXXXXXXXXX", id)
+	}
+
+	kind, ok := idKinds[prefix]
+	if !ok {
+		return "", fmt.Errorf("%q has the unknown ID prefix %q", id, prefix+"_")
+	}
+
+	return kind, nil
+}
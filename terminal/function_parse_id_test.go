@@ -0,0 +1,65 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestIDKind(t *testing.T) {
+	testCases := []struct {
+		id        string
+		expected  string
+		expectErr bool
+	}{
+		{id: "shp_01JNH7GKX0Q8AG5KR5F4A3VHTB", expected: "address"},
+		{id: "crd_01JNH7GKX0Q8AG5KR5F4A3VHTB", expected: "payment_card"},
+		{id: "ord_01JNH7GKX0Q8AG5KR5F4A3VHTB", expected: "coffee_order"},
+		{id: "var_9U04ZMMHXK", expected: "product_variant"},
+		{id: "sub_01JNH7GKX0Q8AG5KR5F4A3VHTB", expected: "subscription"},
+		{id: "xyz_01JNH7GKX0Q8AG5KR5F4A3VHTB", expectErr: true},
+		{id: "shp_", expectErr: true},
+		{id: "01JNH7GKX0Q8AG5KR5F4A3VHTB", expectErr: true},
+		{id: "", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			kind, err := idKind(tc.id)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got kind %s", kind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if kind != tc.expected {
+				t.Errorf("Expected kind %s, got %s", tc.expected, kind)
+			}
+		})
+	}
+}
+
+func TestAccParseIDFunction_address(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAddressConfig(providerConfig, false) + `
+output "kind" {
+  value = provider::terminal::parse_id(terminal_address.test.id)
+}
+`,
+				Check: resource.TestCheckOutput("kind", "address"),
+			},
+		},
+	})
+}
@@ -0,0 +1,275 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// nonIdentifierChars matches runs of characters that can't appear in a
+// Terraform resource name
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ImportOptions controls what GenerateImportConfig includes
+type ImportOptions struct {
+	// OrdersSince includes the orders created at or after this time. The
+	// zero time leaves orders out.
+	OrdersSince time.Time
+}
+
+// ImportConfig is Terraform configuration that adopts the objects of an
+// existing account
+type ImportConfig struct {
+	// Files maps .tf file names to their contents. Files without any
+	// resources are left out.
+	Files map[string][]byte
+	// Warnings describe the objects that couldn't be included
+	Warnings []string
+}
+
+// importWriter builds one generated .tf file
+type importWriter struct {
+	file  *hclwrite.File
+	names map[string]bool
+}
+
+func newImportWriter() *importWriter {
+	f := hclwrite.NewEmptyFile()
+	f.Body().AppendUnstructuredTokens(hclComment("Generated by terminal-coffee-import. Review before running terraform apply."))
+
+	return &importWriter{file: f, names: map[string]bool{}}
+}
+
+// GenerateImportConfig lists the addresses, cards, subscriptions and recent
+// orders of the account and returns resource definitions for them along with
+// import blocks, which Terraform 1.5 and later apply with the next plan.
+// References between the generated resources use resource attributes rather
+// than literal IDs.
+func GenerateImportConfig(ctx context.Context, client *SDKClient, opts ImportOptions) (*ImportConfig, error) {
+	config := &ImportConfig{Files: map[string][]byte{}}
+
+	addresses, err := client.ListAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addressRefs := map[string]hcl.Traversal{}
+	w := newImportWriter()
+	for _, address := range addresses {
+		name := w.add("terminal_address", address.Name, address.ID)
+		addressRefs[address.ID] = resourceAttr("terminal_address", name, "id")
+
+		body := w.resource("terminal_address", name)
+		body.SetAttributeValue("name", cty.StringVal(address.Name))
+		body.SetAttributeValue("street1", cty.StringVal(address.Street1))
+		if address.Street2 != "" {
+			body.SetAttributeValue("street2", cty.StringVal(address.Street2))
+		}
+		body.SetAttributeValue("city", cty.StringVal(address.City))
+		if address.State != "" {
+			body.SetAttributeValue("state", cty.StringVal(address.State))
+		}
+		body.SetAttributeValue("zip", cty.StringVal(address.Zip))
+		body.SetAttributeValue("country", cty.StringVal(address.Country))
+		if address.Phone != "" {
+			body.SetAttributeValue("phone", cty.StringVal(address.Phone))
+		}
+	}
+	w.save(config, "addresses.tf", len(addresses))
+
+	cards, err := client.ListCards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cardRefs := map[string]hcl.Traversal{}
+	w = newImportWriter()
+	for _, card := range cards {
+		name := w.add("terminal_payment_card", card.Brand+"_"+card.Last4, card.ID)
+		cardRefs[card.ID] = resourceAttr("terminal_payment_card", name, "id")
+
+		// Stripe tokens can't be read back, so each card's token is an input
+		// variable rather than a made-up value that would be sent if the
+		// card were ever created again
+		variable := name + "_token"
+		w.variable(variable, fmt.Sprintf("Stripe token for the %s card ending in %s. Imported cards only store it, but it is sent if the card has to be created again.", card.Brand, card.Last4))
+
+		body := w.resource("terminal_payment_card", name)
+		body.SetAttributeTraversal("token", hcl.Traversal{
+			hcl.TraverseRoot{Name: "var"},
+			hcl.TraverseAttr{Name: variable},
+		})
+	}
+	w.save(config, "payment_cards.tf", len(cards))
+
+	subscriptions, err := client.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w = newImportWriter()
+	for _, subscription := range subscriptions {
+		name := w.add("terminal_subscription", strings.ToLower(subscription.ID), subscription.ID)
+
+		body := w.resource("terminal_subscription", name)
+		body.SetAttributeValue("product_variant_id", cty.StringVal(subscription.ProductVariantID))
+		body.SetAttributeValue("quantity", cty.NumberIntVal(int64(subscription.Quantity)))
+		setReference(body, "address_id", subscription.AddressID, addressRefs)
+		setReference(body, "card_id", subscription.CardID, cardRefs)
+
+		schedule := []hclwrite.ObjectAttrTokens{{
+			Name:  hclwrite.TokensForIdentifier("type"),
+			Value: hclwrite.TokensForValue(cty.StringVal(subscription.Schedule.Type)),
+		}}
+		if subscription.Schedule.Interval != 0 {
+			schedule = append(schedule, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForIdentifier("interval"),
+				Value: hclwrite.TokensForValue(cty.NumberIntVal(int64(subscription.Schedule.Interval))),
+			})
+		}
+		body.SetAttributeRaw("schedule", hclwrite.TokensForObject(schedule))
+	}
+	w.save(config, "subscriptions.tf", len(subscriptions))
+
+	if opts.OrdersSince.IsZero() {
+		return config, nil
+	}
+
+	orders, err := client.ListOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter := orderFilter{CreatedAfter: opts.OrdersSince}
+	w = newImportWriter()
+	count := 0
+	for _, order := range orders {
+		if !filter.matches(order) {
+			continue
+		}
+
+		// Orders don't record their address and card, so they are inferred
+		// the same way as on import and written into the import ID
+		addressID, cardID := orderSources(addresses, cards, order)
+		if addressID == "" || cardID == "" {
+			config.Warnings = append(config.Warnings, fmt.Sprintf(
+				"Left out order %s: its saved address or payment card can't be inferred. Import it with \"terraform import terminal_coffee_order.<name> %s:<address_id>:<card_id>\"",
+				order.ID, order.ID,
+			))
+			continue
+		}
+
+		name := w.add("terminal_coffee_order", strings.ToLower(order.ID), fmt.Sprintf("%s:%s:%s", order.ID, addressID, cardID))
+		count++
+
+		body := w.resource("terminal_coffee_order", name)
+		setReference(body, "address_id", addressID, addressRefs)
+		setReference(body, "card_id", cardID, cardRefs)
+		for _, variantID := range slices.Sorted(maps.Keys(order.Variants)) {
+			body.AppendNewline()
+			item := body.AppendNewBlock("item", nil).Body()
+			item.SetAttributeValue("variant_id", cty.StringVal(variantID))
+			item.SetAttributeValue("quantity", cty.NumberIntVal(int64(order.Variants[variantID])))
+		}
+	}
+	w.save(config, "coffee_orders.tf", count)
+
+	return config, nil
+}
+
+// add reserves a resource name derived from label and writes an import block
+// for it with the given import ID. It returns the resource name.
+func (w *importWriter) add(resourceType, label, importID string) string {
+	base := importResourceName(label, strings.TrimPrefix(resourceType, "terminal_"))
+	name := base
+	for i := 2; w.names[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	w.names[name] = true
+
+	body := w.file.Body()
+	body.AppendNewline()
+	block := body.AppendNewBlock("import", nil).Body()
+	block.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	block.SetAttributeValue("id", cty.StringVal(importID))
+
+	return name
+}
+
+// variable appends a required, sensitive string variable
+func (w *importWriter) variable(name, description string) {
+	body := w.file.Body()
+	body.AppendNewline()
+
+	block := body.AppendNewBlock("variable", []string{name}).Body()
+	block.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	block.SetAttributeValue("description", cty.StringVal(description))
+	block.SetAttributeValue("sensitive", cty.True)
+}
+
+// resource appends a resource block and returns its body
+func (w *importWriter) resource(resourceType, name string) *hclwrite.Body {
+	body := w.file.Body()
+	body.AppendNewline()
+
+	return body.AppendNewBlock("resource", []string{resourceType, name}).Body()
+}
+
+// save adds the file to the config unless it has no resources
+func (w *importWriter) save(config *ImportConfig, name string, count int) {
+	if count == 0 {
+		return
+	}
+
+	config.Files[name] = hclwrite.Format(w.file.Bytes())
+}
+
+// importResourceName turns a label such as an address name into a Terraform
+// resource name, falling back to the resource kind for labels without any
+// usable characters
+func importResourceName(label, fallback string) string {
+	name := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	switch {
+	case name == "":
+		return fallback
+	case name[0] >= '0' && name[0] <= '9':
+		return fallback + "_" + name
+	default:
+		return name
+	}
+}
+
+// resourceAttr returns a reference to an attribute of a resource
+func resourceAttr(resourceType, name, attribute string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+		hcl.TraverseAttr{Name: attribute},
+	}
+}
+
+// setReference sets an ID attribute, referring to the generated resource for
+// the ID when there is one
+func setReference(body *hclwrite.Body, attribute, id string, refs map[string]hcl.Traversal) {
+	if ref, ok := refs[id]; ok {
+		body.SetAttributeTraversal(attribute, ref)
+		return
+	}
+
+	body.SetAttributeValue(attribute, cty.StringVal(id))
+}
+
+// hclComment returns the tokens of a line comment
+func hclComment(text string) hclwrite.Tokens {
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# " + text + "\n")},
+	}
+}
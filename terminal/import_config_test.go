@@ -0,0 +1,207 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal/mockapi"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestImportResourceName(t *testing.T) {
+	testCases := []struct {
+		label    string
+		expected string
+	}{
+		{label: "HQ", expected: "hq"},
+		{label: "Test User", expected: "test_user"},
+		{label: "  O'Brien & Sons, Ltd. ", expected: "o_brien_sons_ltd"},
+		{label: "Visa_4242", expected: "visa_4242"},
+		{label: "42 Wallaby Way", expected: "address_42_wallaby_way"},
+		{label: "東京", expected: "address"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			if got := importResourceName(tc.label, "address"); got != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerateImportConfig(t *testing.T) {
+	_, config := testImportAccount(t)
+
+	for _, expected := range []string{
+		"import {\n  to = terminal_address.test_user\n",
+		`resource "terminal_address" "test_user_2"`,
+		"to = terminal_payment_card.visa_4242",
+		"variable \"visa_4242_token\" {\n  type        = string\n",
+		"sensitive   = true",
+		"token = var.visa_4242_token",
+		"address_id         = terminal_address.test_user.id",
+		"card_id            = terminal_payment_card.visa_4242.id",
+		"type     = \"weekly\"\n    interval = 2",
+		"address_id = terminal_address.test_user_2.id",
+		"quantity   = 2",
+	} {
+		if !strings.Contains(config, expected) {
+			t.Errorf("Expected generated config to contain %q, got:\n%s", expected, config)
+		}
+	}
+}
+
+func TestGenerateImportConfig_listsOnce(t *testing.T) {
+	ctx := context.Background()
+
+	// Addresses and cards are listed once however many orders are inferred
+	api := mockapi.New()
+	lists := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			lists[r.URL.Path]++
+		}
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	address, err := client.CreateAddress(ctx, &Address{Name: "Test User", Street1: "123 Test St", City: "Test City", State: "CA", Zip: "12345", Country: "US"})
+	if err != nil {
+		t.Fatalf("Failed to create address: %v", err)
+	}
+	card, err := client.CreateCard(ctx, &Card{Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("Failed to create card: %v", err)
+	}
+	for range 3 {
+		if _, err := client.CreateOrder(ctx, &Order{
+			AddressID: address.ID,
+			CardID:    card.ID,
+			Variants:  map[string]int{testAccVariantID(): 1},
+		}); err != nil {
+			t.Fatalf("Failed to create order: %v", err)
+		}
+	}
+
+	generated, err := GenerateImportConfig(ctx, client, ImportOptions{OrdersSince: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to generate config: %v", err)
+	}
+	if len(generated.Warnings) > 0 {
+		t.Fatalf("Unexpected warnings: %v", generated.Warnings)
+	}
+	for _, path := range []string{"/address", "/card"} {
+		if lists[path] != 1 {
+			t.Errorf("Expected %s to be listed once, got %d", path, lists[path])
+		}
+	}
+}
+
+func TestAccImportConfig_roundTrip(t *testing.T) {
+	providerConfig, generated := testImportAccount(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				// The generated resources match what is imported, so nothing
+				// changes beyond storing the card's token
+				Config: providerConfig + generated,
+				ConfigVariables: config.Variables{
+					"visa_4242_token": config.StringVariable("tok_visa"),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terminal_address.test_user", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("terminal_address.test_user_2", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("terminal_payment_card.visa_4242", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
+
+// testImportAccount fills a fresh mock account with objects created outside
+// Terraform and returns the provider block and the config generated for it
+func testImportAccount(t *testing.T) (string, string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Both addresses have the same name, so their resource names must differ
+	var addressIDs []string
+	for _, street := range []string{"123 Test St", "456 Test St"} {
+		address, err := client.CreateAddress(ctx, &Address{Name: "Test User", Street1: street, City: "Test City", State: "CA", Zip: "12345", Country: "US"})
+		if err != nil {
+			t.Fatalf("Failed to create address: %v", err)
+		}
+		addressIDs = append(addressIDs, address.ID)
+	}
+	card, err := client.CreateCard(ctx, &Card{Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("Failed to create card: %v", err)
+	}
+	if _, err := client.CreateSubscription(ctx, &Subscription{
+		ProductVariantID: testAccVariantID(),
+		Quantity:         1,
+		AddressID:        addressIDs[0],
+		CardID:           card.ID,
+		Schedule:         SubscriptionSchedule{Type: "weekly", Interval: 2},
+	}); err != nil {
+		t.Fatalf("Failed to create subscription: %v", err)
+	}
+	if _, err := client.CreateOrder(ctx, &Order{
+		AddressID: addressIDs[1],
+		CardID:    card.ID,
+		Variants:  map[string]int{testAccVariantID(): 2},
+	}); err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+
+	generated, err := GenerateImportConfig(ctx, client, ImportOptions{OrdersSince: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to generate config: %v", err)
+	}
+	if len(generated.Warnings) > 0 {
+		t.Fatalf("Unexpected warnings: %v", generated.Warnings)
+	}
+
+	var config strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(generated.Files)) {
+		config.Write(generated.Files[name])
+	}
+
+	return fmt.Sprintf(`
+provider "terminal" {
+  api_endpoint = %q
+  api_token    = %q
+}
+`, server.URL, mockapi.DefaultToken), config.String()
+}
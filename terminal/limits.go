@@ -0,0 +1,156 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// orderLimits are the spending guardrails set in the provider block. Zero
+// values mean no limit.
+type orderLimits struct {
+	// MaxOrderTotal is the most a single order may cost, in cents
+	MaxOrderTotal int64
+	// MaxItemsPerOrder is the most units a single order may contain
+	MaxItemsPerOrder int64
+	// Budget caps the spend across all orders in a calendar period
+	Budget *orderBudget
+}
+
+// orderBudget caps the spend across all orders placed in the current period
+type orderBudget struct {
+	// Period is one of "day", "week", "month" or "year"
+	Period string
+	// Limit is the most that may be spent in the period, in cents
+	Limit int64
+}
+
+// budgetPeriods are the accepted values of budget.period
+var budgetPeriods = []string{"day", "week", "month", "year"}
+
+// checkOrderLimits reports an error for each limit a new order would break.
+// totalCents is what the order is expected to cost including shipping, as
+// orders in the history do: the estimate for coffee orders, or the API's
+// quote for carts. The order history is only fetched when a budget is set.
+func (c *SDKClient) checkOrderLimits(ctx context.Context, items int64, totalCents int64, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+	limits := c.Limits
+
+	if limits.MaxItemsPerOrder > 0 && items > limits.MaxItemsPerOrder {
+		diags.AddError(
+			"Order exceeds max_items_per_order",
+			fmt.Sprintf("The order contains %d items, but the provider allows at most %d per order.", items, limits.MaxItemsPerOrder),
+		)
+	}
+
+	if limits.MaxOrderTotal > 0 && totalCents > limits.MaxOrderTotal {
+		diags.AddError(
+			"Order exceeds max_order_total",
+			fmt.Sprintf("The order would cost %s, but the provider allows at most %s per order.", formatCents(totalCents), formatCents(limits.MaxOrderTotal)),
+		)
+	}
+
+	if limits.Budget == nil {
+		return diags
+	}
+
+	orders, err := c.ListOrders(ctx)
+	if err != nil {
+		diags.AddError("Error checking order budget", err.Error())
+		return diags
+	}
+
+	start := budgetPeriodStart(limits.Budget.Period, now)
+	spent, undated := spentSince(orders, start)
+	if len(undated) > 0 {
+		diags.AddError(
+			"Error checking order budget",
+			fmt.Sprintf("The API didn't say when orders %s were placed, so the spend since %s can't be totalled. "+
+				"Order creation times come from the API's created field, which the Terminal SDK doesn't model. "+
+				"Remove the budget block to place orders without it.",
+				strings.Join(undated, ", "), start.Format(time.DateOnly)),
+		)
+		return diags
+	}
+
+	if spent+totalCents > limits.Budget.Limit {
+		diags.AddError(
+			"Order exceeds budget",
+			fmt.Sprintf(
+				"The order would cost %s, but only %s of the %s %s budget remains (%s spent since %s).",
+				formatCents(totalCents),
+				formatCents(max(limits.Budget.Limit-spent, 0)),
+				formatCents(limits.Budget.Limit),
+				limits.Budget.Period,
+				formatCents(spent),
+				start.Format(time.DateOnly),
+			),
+		)
+	}
+
+	return diags
+}
+
+// budgetPeriodStart returns the start of the calendar period containing now,
+// in UTC. Weeks start on Monday.
+func budgetPeriodStart(period string, now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case "day":
+		return today
+	case "week":
+		return today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	case "year":
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// spentSince totals the subtotal and shipping of orders placed at or after
+// start, in cents. Orders are only dated by the API's created field, which
+// the SDK doesn't model. An order without one is known to predate start if a
+// later order in the history does, and the IDs of any others are returned
+// since they can't be placed in or out of the period.
+func spentSince(orders []*Order, start time.Time) (spent int64, undated []string) {
+	// Orders up to the latest one placed before start are all older
+	before := int64(-1)
+	for _, order := range orders {
+		if created, err := time.Parse(time.RFC3339, order.CreatedAt); err == nil && created.Before(start) {
+			before = max(before, order.Index)
+		}
+	}
+
+	for _, order := range orders {
+		if order.Index <= before {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, order.CreatedAt)
+		if err != nil {
+			undated = append(undated, order.ID)
+			continue
+		}
+		if created.Before(start) {
+			continue
+		}
+		spent += order.Amount.Subtotal + order.Amount.Shipping
+	}
+
+	return spent, undated
+}
+
+// formatCents formats an amount in cents as dollars
+func formatCents(cents int64) string {
+	return fmt.Sprintf("$%.2f", float64(cents)/100.0)
+}
+
+// dollarsToCents converts a configured dollar amount to cents
+func dollarsToCents(dollars float64) int64 {
+	return int64(math.Round(dollars * 100))
+}
@@ -0,0 +1,129 @@
+package terminal
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrder_limits(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOrderLimitsConfig(providerConfig, "max_items_per_order = 2", 3),
+				ExpectError: regexp.MustCompile(`Order exceeds max_items_per_order`),
+			},
+			{
+				Config:      testAccOrderLimitsConfig(providerConfig, "max_order_total = 10", 1),
+				ExpectError: regexp.MustCompile(`Order exceeds max_order_total`),
+			},
+			{
+				Config: testAccOrderLimitsConfig(providerConfig, `
+budget {
+  period = "month"
+  limit  = 30
+}
+`, 1),
+			},
+			{
+				// The first order already used most of the month's budget
+				Config: testAccOrderLimitsConfig(providerConfig, `
+budget {
+  period = "month"
+  limit  = 30
+}
+`, 1) + `
+resource "terminal_coffee_order" "second" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+
+  item {
+    variant_id = terminal_coffee_order.test.item[0].variant_id
+    quantity   = 1
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Order exceeds budget`),
+			},
+		},
+	})
+}
+
+// testAccOrderLimitsConfig adds spending guardrails to the provider block and
+// orders quantity units of the test variant
+func testAccOrderLimitsConfig(providerConfig, limits string, quantity int) string {
+	providerConfig = strings.TrimSuffix(strings.TrimSpace(providerConfig), "}") + limits + "\n}\n"
+
+	return testAccAddressConfig(providerConfig, false) + fmt.Sprintf(`
+resource "terminal_payment_card" "test" {
+  token = %q
+}
+
+resource "terminal_coffee_order" "test" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+
+  item {
+    variant_id = %q
+    quantity   = %d
+  }
+}
+`, testAccStripeToken(), testAccVariantID(), quantity)
+}
+
+func TestBudgetPeriodStart(t *testing.T) {
+	// Early on Wednesday east of UTC, but still Tuesday in UTC
+	now := time.Date(2025, time.March, 12, 1, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	testCases := map[string]time.Time{
+		"day":   time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC),
+		"week":  time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC),
+		"month": time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		"year":  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for period, expected := range testCases {
+		t.Run(period, func(t *testing.T) {
+			if start := budgetPeriodStart(period, now); !start.Equal(expected) {
+				t.Errorf("Expected %s, got %s", expected, start)
+			}
+		})
+	}
+
+	// Weeks start on Monday, so a Sunday belongs to the week before
+	sunday := time.Date(2025, time.March, 16, 12, 0, 0, 0, time.UTC)
+	if start := budgetPeriodStart("week", sunday); !start.Equal(time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the week to start on March 10, got %s", start)
+	}
+}
+
+func TestSpentSince(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	orders := []*Order{
+		{ID: "ord_0", Index: 0, Amount: OrderAmount{Subtotal: 6400, Shipping: 0}},
+		{ID: "ord_1", Index: 1, Amount: OrderAmount{Subtotal: 2200, Shipping: 0}, CreatedAt: "2025-02-28T23:59:59Z"},
+		{ID: "ord_2", Index: 2, Amount: OrderAmount{Subtotal: 4400, Shipping: 1500}, CreatedAt: "2025-03-01T00:00:00Z"},
+		{ID: "ord_3", Index: 3, Amount: OrderAmount{Subtotal: 2200, Shipping: 0}, CreatedAt: "2025-03-11T09:00:00Z"},
+	}
+
+	// The undated ord_0 came before ord_1, so it is known to predate the period
+	spent, undated := spentSince(orders, start)
+	if spent != 8100 || len(undated) != 0 {
+		t.Errorf("Expected 8100 cents with every order dated, got %d and undated %v", spent, undated)
+	}
+
+	// An undated order after the period started can't be placed in or out of it
+	orders = append(orders, &Order{ID: "ord_4", Index: 4, Amount: OrderAmount{Subtotal: 6400, Shipping: 0}})
+	if _, undated := spentSince(orders, start); !slices.Equal(undated, []string{"ord_4"}) {
+		t.Errorf("Expected ord_4 to be undated, got %v", undated)
+	}
+}
@@ -0,0 +1,73 @@
+package mockapi
+
+// catalog returns the products served by a fresh API. Variant IDs are stable
+// so tests and examples can refer to them directly.
+func catalog() []*product {
+	return []*product{
+		{
+			ID:           "prd_01JNH7GKWYRHX45GPRZS3M7A4X",
+			Name:         "segfault",
+			Description:  "A dark roast with notes of stack traces and regret.",
+			Order:        0,
+			Subscription: "allowed",
+			Tags:         productTags{Color: "#169FC1", Featured: true, MarketNA: true, MarketEU: true},
+			Variants: []variant{
+				{ID: "var_9U04ZMMHXK", Name: "12oz", Price: 2200},
+			},
+		},
+		{
+			ID:           "prd_01JNH7GKWZ0XC9P0PDMCZJ2ZCN",
+			Name:         "dark mode",
+			Description:  "Smooth, low-light coffee for late night deploys.",
+			Order:        1,
+			Subscription: "allowed",
+			Tags:         productTags{Color: "#118B39", MarketNA: true},
+			Variants: []variant{
+				{ID: "var_01JNH7GKX0Q8AG5KR5F4A3VHTB", Name: "12oz", Price: 2200},
+				{ID: "var_01JNH7GKX1ZC1T4WD2N4JQ3SZF", Name: "2lb", Price: 6400},
+			},
+		},
+		{
+			ID:           "prd_01JNH7GKX2A6YJ3G9W7Z0VBXWQ",
+			Name:         "[object Object]",
+			Description:  "A medium roast that is exactly what it says it is.",
+			Order:        2,
+			Subscription: "allowed",
+			Tags:         productTags{Color: "#F5BB1D", MarketNA: true, MarketEU: true},
+			Variants: []variant{
+				{ID: "var_01JNH7GKX3M1W9GQ2RYD8Y0H7K", Name: "12oz", Price: 2200},
+			},
+		},
+		{
+			ID:           "prd_01JNH7GKX4XB6Z8J5N1V3T9QEP",
+			Name:         "cron",
+			Description:  "Fresh coffee on a schedule. Subscription only.",
+			Order:        3,
+			Subscription: "required",
+			Tags:         productTags{Color: "#D53C81", MarketNA: true},
+			Variants: []variant{
+				{ID: "var_01JNH7GKX5H2D4R6T8V0X2Z4B6", Name: "12oz", Price: 2000},
+			},
+		},
+	}
+}
+
+// shippingCost returns the shipping charge in cents for an order to the given
+// country: free within the US and a flat rate elsewhere
+func shippingCost(country string) int64 {
+	if country == "" || country == "US" {
+		return 0
+	}
+
+	return 1500
+}
+
+// shippingService returns the carrier and delivery timeframe used for orders
+// to the given country
+func shippingService(country string) (service, timeframe string) {
+	if country == "" || country == "US" {
+		return "USPS", "3-5 days"
+	}
+
+	return "DHL", "7-14 days"
+}
@@ -0,0 +1,681 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+func (a *API) serveProduct(w http.ResponseWriter, r *http.Request, rest []string) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if len(rest) == 0 {
+		writeData(w, a.products)
+		return
+	}
+
+	for _, p := range a.products {
+		if p.ID == rest[0] {
+			writeData(w, p)
+			return
+		}
+	}
+	writeNotFound(w)
+}
+
+func (a *API) serveProfile(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) != 0 {
+		writeNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, map[string]any{"user": a.profile})
+	case http.MethodPut:
+		var body struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}
+		if !decode(w, r, &body) {
+			return
+		}
+		if body.Name == "" || !strings.Contains(body.Email, "@") {
+			writeError(w, http.StatusBadRequest, "validation", "name and a valid email are required")
+			return
+		}
+		a.profile.Name = body.Name
+		a.profile.Email = body.Email
+		writeData(w, map[string]any{"user": a.profile})
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (a *API) serveAddress(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeData(w, sortedValues(a.addresses))
+		case http.MethodPost:
+			var body address
+			if !decode(w, r, &body) {
+				return
+			}
+			if body.Name == "" || body.Street1 == "" || body.City == "" || body.Zip == "" || body.Country == "" {
+				writeError(w, http.StatusBadRequest, "validation", "name, street1, city, zip and country are required")
+				return
+			}
+			body.ID = a.newID("shp")
+			a.addresses[body.ID] = &body
+			writeData(w, body.ID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	addr, ok := a.addresses[rest[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, addr)
+	case http.MethodDelete:
+		delete(a.addresses, addr.ID)
+		writeData(w, "ok")
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (a *API) serveCard(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeData(w, sortedValues(a.cards))
+		case http.MethodPost:
+			var body struct {
+				Token string `json:"token"`
+			}
+			if !decode(w, r, &body) {
+				return
+			}
+			brand, last4, ok := stripeTestCard(body.Token)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "validation", "invalid Stripe token")
+				return
+			}
+			c := &card{
+				ID:         a.newID("crd"),
+				Brand:      brand,
+				Last4:      last4,
+				Expiration: cardExpiration{Month: 12, Year: int64(a.now().Year() + 3)},
+			}
+			a.cards[c.ID] = c
+			writeData(w, c.ID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	if rest[0] == "collect" {
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w)
+			return
+		}
+		writeData(w, map[string]any{
+			"url": "https://checkout.stripe.com/c/pay/" + strings.ToLower(a.newID("cs_test")),
+		})
+		return
+	}
+
+	c, ok := a.cards[rest[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, c)
+	case http.MethodDelete:
+		delete(a.cards, c.ID)
+		writeData(w, "ok")
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (a *API) serveCart(w http.ResponseWriter, r *http.Request, rest []string) {
+	action := ""
+	if len(rest) > 0 {
+		action = rest[0]
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeData(w, a.cart)
+	case action == "" && r.Method == http.MethodDelete:
+		a.cart = newCart()
+		writeData(w, "ok")
+	case action == "item" && r.Method == http.MethodPut:
+		var body struct {
+			ProductVariantID string `json:"productVariantID"`
+			Quantity         int64  `json:"quantity"`
+		}
+		if !decode(w, r, &body) {
+			return
+		}
+		if _, ok := a.variant(body.ProductVariantID); !ok {
+			writeError(w, http.StatusBadRequest, "validation", fmt.Sprintf("unknown product variant %q", body.ProductVariantID))
+			return
+		}
+		a.setCartItem(body.ProductVariantID, body.Quantity)
+		writeData(w, a.cart)
+	case action == "address" && r.Method == http.MethodPut:
+		var body struct {
+			AddressID string `json:"addressID"`
+		}
+		if !decode(w, r, &body) {
+			return
+		}
+		if _, ok := a.addresses[body.AddressID]; !ok {
+			writeError(w, http.StatusBadRequest, "validation", fmt.Sprintf("unknown address %q", body.AddressID))
+			return
+		}
+		a.cart.AddressID = body.AddressID
+		a.recalculateCart()
+		writeData(w, "ok")
+	case action == "card" && r.Method == http.MethodPut:
+		var body struct {
+			CardID string `json:"cardID"`
+		}
+		if !decode(w, r, &body) {
+			return
+		}
+		if _, ok := a.cards[body.CardID]; !ok {
+			writeError(w, http.StatusBadRequest, "validation", fmt.Sprintf("unknown card %q", body.CardID))
+			return
+		}
+		a.cart.CardID = body.CardID
+		writeData(w, "ok")
+	case action == "convert" && r.Method == http.MethodPost:
+		variants := make(map[string]int64)
+		for _, item := range a.cart.Items {
+			variants[item.ProductVariantID] = item.Quantity
+		}
+		o, status, message := a.placeOrder(a.cart.AddressID, a.cart.CardID, variants)
+		if o == nil {
+			writeError(w, status, "validation", message)
+			return
+		}
+		a.cart = newCart()
+		writeData(w, o)
+	default:
+		writeNotFound(w)
+	}
+}
+
+// setCartItem sets the quantity of a variant in the cart, removing it when
+// the quantity is zero
+func (a *API) setCartItem(variantID string, quantity int64) {
+	items := make([]cartItem, 0, len(a.cart.Items))
+	found := false
+	for _, item := range a.cart.Items {
+		if item.ProductVariantID == variantID {
+			found = true
+			item.Quantity = quantity
+		}
+		if item.Quantity > 0 {
+			items = append(items, item)
+		}
+	}
+	if !found && quantity > 0 {
+		items = append(items, cartItem{
+			ID:               a.newID("itm"),
+			ProductVariantID: variantID,
+			Quantity:         quantity,
+		})
+	}
+	a.cart.Items = items
+	a.recalculateCart()
+}
+
+// newCart returns an empty cart
+func newCart() *cart {
+	return &cart{Items: []cartItem{}}
+}
+
+// recalculateCart refreshes the cart totals after a change
+func (a *API) recalculateCart() {
+	var subtotal int64
+	for i, item := range a.cart.Items {
+		v, _ := a.variant(item.ProductVariantID)
+		a.cart.Items[i].Subtotal = v.Price * item.Quantity
+		subtotal += a.cart.Items[i].Subtotal
+	}
+
+	var shipping int64
+	a.cart.Shipping = cartShipping{}
+	if addr, ok := a.addresses[a.cart.AddressID]; ok {
+		shipping = shippingCost(addr.Country)
+		service, timeframe := shippingService(addr.Country)
+		a.cart.Shipping = cartShipping{Service: service, Timeframe: timeframe}
+	}
+
+	a.cart.Subtotal = subtotal
+	a.cart.Amount = cartAmount{
+		Subtotal: subtotal,
+		Shipping: shipping,
+		Total:    subtotal + shipping,
+	}
+}
+
+func (a *API) serveOrder(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeData(w, a.orders)
+		case http.MethodPost:
+			var body struct {
+				AddressID string           `json:"addressID"`
+				CardID    string           `json:"cardID"`
+				Variants  map[string]int64 `json:"variants"`
+			}
+			if !decode(w, r, &body) {
+				return
+			}
+			o, status, message := a.placeOrder(body.AddressID, body.CardID, body.Variants)
+			if o == nil {
+				writeError(w, status, "validation", message)
+				return
+			}
+			writeData(w, o.ID)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	for _, o := range a.orders {
+		if o.ID == rest[0] {
+			writeData(w, o)
+			return
+		}
+	}
+	writeNotFound(w)
+}
+
+// placeOrder validates and records a new order. On failure it returns a nil
+// order along with the status code and message to respond with.
+func (a *API) placeOrder(addressID, cardID string, variants map[string]int64) (*order, int, string) {
+	addr, ok := a.addresses[addressID]
+	if !ok {
+		return nil, http.StatusBadRequest, fmt.Sprintf("unknown address %q", addressID)
+	}
+	if _, ok := a.cards[cardID]; !ok {
+		return nil, http.StatusBadRequest, fmt.Sprintf("unknown card %q", cardID)
+	}
+	if len(variants) == 0 {
+		return nil, http.StatusBadRequest, "an order needs at least one item"
+	}
+
+	variantIDs := make([]string, 0, len(variants))
+	for id := range variants {
+		variantIDs = append(variantIDs, id)
+	}
+	sort.Strings(variantIDs)
+
+	o := &order{
+		ID:      a.newID("ord"),
+		Index:   int64(len(a.orders)),
+		Created: a.now().UTC().Format(time.RFC3339),
+		Shipping: orderShipping{
+			Name:     addr.Name,
+			Street1:  addr.Street1,
+			Street2:  addr.Street2,
+			City:     addr.City,
+			Province: addr.Province,
+			Zip:      addr.Zip,
+			Country:  addr.Country,
+			Phone:    addr.Phone,
+		},
+	}
+
+	for _, id := range variantIDs {
+		v, ok := a.variant(id)
+		if !ok {
+			return nil, http.StatusBadRequest, fmt.Sprintf("unknown product variant %q", id)
+		}
+		quantity := variants[id]
+		if quantity < 1 {
+			return nil, http.StatusBadRequest, fmt.Sprintf("invalid quantity %d for %q", quantity, id)
+		}
+		item := orderItem{
+			ID:               a.newID("itm"),
+			Amount:           v.Price * quantity,
+			Quantity:         quantity,
+			Description:      v.Name,
+			ProductVariantID: id,
+		}
+		o.Items = append(o.Items, item)
+		o.Amount.Subtotal += item.Amount
+	}
+	o.Amount.Shipping = shippingCost(addr.Country)
+	// The carrier is known up front, the tracking number only once shipped
+	o.Tracking.Service, _ = shippingService(addr.Country)
+
+	a.orders = append(a.orders, o)
+
+	return o, 0, ""
+}
+
+// SetNow replaces the clock used to timestamp new records, so tests can
+// create orders and tokens in the past
+func (a *API) SetNow(now func() time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.now = now
+}
+
+// ShipOrder attaches tracking information to an order, as happens when the
+// Terminal warehouse ships it
+func (a *API) ShipOrder(orderID, number, service, url string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, o := range a.orders {
+		if o.ID == orderID {
+			o.Tracking = orderTracking{Number: number, Service: service, URL: url}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("order %s not found", orderID)
+}
+
+// DeliverOrder marks a shipped order as delivered by the carrier
+func (a *API) DeliverOrder(orderID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, o := range a.orders {
+		if o.ID == orderID {
+			if o.Tracking.Number == "" {
+				return fmt.Errorf("order %s has not shipped", orderID)
+			}
+			o.Tracking.Status = "DELIVERED"
+			return nil
+		}
+	}
+
+	return fmt.Errorf("order %s not found", orderID)
+}
+
+func (a *API) serveSubscription(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeData(w, sortedValues(a.subscriptions))
+		case http.MethodPost:
+			var body subscription
+			if !decode(w, r, &body) {
+				return
+			}
+			if message := a.validateSubscription(&body); message != "" {
+				writeError(w, http.StatusBadRequest, "validation", message)
+				return
+			}
+			body.ID = a.newID("sub")
+			body.Next = a.nextDelivery(body.Schedule).Format(time.RFC3339)
+			a.subscriptions[body.ID] = &body
+			writeData(w, "ok")
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	sub, ok := a.subscriptions[rest[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, sub)
+	case http.MethodDelete:
+		delete(a.subscriptions, sub.ID)
+		writeData(w, "ok")
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// validateSubscription returns a validation message, or "" if the
+// subscription is acceptable
+func (a *API) validateSubscription(s *subscription) string {
+	if _, ok := a.addresses[s.AddressID]; !ok {
+		return fmt.Sprintf("unknown address %q", s.AddressID)
+	}
+	if _, ok := a.cards[s.CardID]; !ok {
+		return fmt.Sprintf("unknown card %q", s.CardID)
+	}
+	if _, ok := a.variant(s.ProductVariantID); !ok {
+		return fmt.Sprintf("unknown product variant %q", s.ProductVariantID)
+	}
+	if s.Quantity < 1 {
+		return "quantity must be at least 1"
+	}
+	switch s.Schedule.Type {
+	case "fixed":
+		s.Schedule.Interval = 0
+	case "weekly":
+		if s.Schedule.Interval < 1 {
+			return "weekly schedules need an interval of at least 1 week"
+		}
+	default:
+		return fmt.Sprintf("unknown schedule type %q", s.Schedule.Type)
+	}
+
+	return ""
+}
+
+// nextDelivery returns the first shipment date for a new subscription
+func (a *API) nextDelivery(schedule subscriptionSchedule) time.Time {
+	weeks := schedule.Interval
+	if schedule.Type == "fixed" {
+		weeks = 4
+	}
+
+	return a.now().UTC().Truncate(24*time.Hour).AddDate(0, 0, int(weeks)*7)
+}
+
+func (a *API) serveToken(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			tokens := sortedValues(a.tokens)
+			masked := make([]token, len(tokens))
+			for i, t := range tokens {
+				masked[i] = maskToken(t)
+			}
+			writeData(w, masked)
+		case http.MethodPost:
+			t := &token{
+				ID:      a.newID("pat"),
+				Created: a.now().UTC().Format(time.RFC3339),
+			}
+			t.Token = "trm_test_" + strings.ToLower(strings.TrimPrefix(t.ID, "pat_"))
+			a.tokens[t.ID] = t
+			writeData(w, map[string]any{"id": t.ID, "token": t.Token})
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	t, ok := a.tokens[rest[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, maskToken(t))
+	case http.MethodDelete:
+		delete(a.tokens, t.ID)
+		writeData(w, "ok")
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// maskToken hides all but the last four characters of a token, as the API
+// only reveals the full value when it is created
+func maskToken(t *token) token {
+	masked := *t
+	masked.Token = maskSecret(t.Token)
+
+	return masked
+}
+
+func (a *API) serveApp(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			apps := sortedValues(a.apps)
+			masked := make([]app, len(apps))
+			for i, ap := range apps {
+				masked[i] = maskApp(ap)
+			}
+			writeData(w, masked)
+		case http.MethodPost:
+			var body struct {
+				Name        string `json:"name"`
+				RedirectURI string `json:"redirectURI"`
+			}
+			if !decode(w, r, &body) {
+				return
+			}
+			if u, err := url.Parse(body.RedirectURI); body.Name == "" || err != nil || !u.IsAbs() {
+				writeError(w, http.StatusBadRequest, "validation", "name and an absolute redirectURI are required")
+				return
+			}
+			ap := &app{
+				ID:          a.newID("cli"),
+				Name:        body.Name,
+				RedirectURI: body.RedirectURI,
+			}
+			ap.Secret = "trm_secret_" + strings.ToLower(strings.TrimPrefix(ap.ID, "cli_"))
+			a.apps[ap.ID] = ap
+			writeData(w, map[string]any{"id": ap.ID, "secret": ap.Secret})
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	ap, ok := a.apps[rest[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, maskApp(ap))
+	case http.MethodDelete:
+		delete(a.apps, ap.ID)
+		writeData(w, "ok")
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// maskApp hides the client secret of an app, which like a token is only
+// revealed when the app is created
+func maskApp(ap *app) app {
+	masked := *ap
+	masked.Secret = maskSecret(ap.Secret)
+
+	return masked
+}
+
+// maskSecret replaces all but the last four characters of a secret with
+// asterisks
+func maskSecret(secret string) string {
+	if len(secret) <= 4 {
+		return secret
+	}
+
+	return strings.Repeat("*", len(secret)-4) + secret[len(secret)-4:]
+}
+
+// variant looks up a product variant in the catalog
+func (a *API) variant(id string) (variant, bool) {
+	for _, p := range a.products {
+		for _, v := range p.Variants {
+			if v.ID == id {
+				return v, true
+			}
+		}
+	}
+
+	return variant{}, false
+}
+
+// stripeTestCard returns the card details for a Stripe test token
+func stripeTestCard(token string) (brand, last4 string, ok bool) {
+	switch token {
+	case "tok_visa":
+		return "Visa", "4242", true
+	case "tok_visa_debit":
+		return "Visa", "5556", true
+	case "tok_mastercard":
+		return "Mastercard", "4444", true
+	case "tok_amex":
+		return "American Express", "8431", true
+	}
+	if strings.HasPrefix(token, "tok_") {
+		return "Visa", "4242", true
+	}
+
+	return "", "", false
+}
+
+// sortedValues returns the values of an ID-keyed map in ID order, which
+// matches creation order for generated IDs
+func sortedValues[T any](m map[string]*T) []*T {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]*T, len(keys))
+	for i, k := range keys {
+		values[i] = m[k]
+	}
+
+	return values
+}
@@ -0,0 +1,156 @@
+package mockapi
+
+// These types mirror the JSON shapes returned by the Terminal API
+
+type product struct {
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Variants     []variant   `json:"variants"`
+	Order        int64       `json:"order"`
+	Subscription string      `json:"subscription,omitempty"`
+	Tags         productTags `json:"tags"`
+}
+
+type productTags struct {
+	App      string `json:"app,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Featured bool   `json:"featured,omitempty"`
+	MarketEU bool   `json:"market_eu,omitempty"`
+	MarketNA bool   `json:"market_na,omitempty"`
+}
+
+type variant struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Price int64  `json:"price"`
+}
+
+type profile struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Email            string `json:"email"`
+	Fingerprint      string `json:"fingerprint"`
+	StripeCustomerID string `json:"stripeCustomerID"`
+}
+
+type address struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Street1  string `json:"street1"`
+	Street2  string `json:"street2,omitempty"`
+	City     string `json:"city"`
+	Province string `json:"province,omitempty"`
+	Zip      string `json:"zip"`
+	Country  string `json:"country"`
+	Phone    string `json:"phone,omitempty"`
+}
+
+type card struct {
+	ID         string         `json:"id"`
+	Brand      string         `json:"brand"`
+	Expiration cardExpiration `json:"expiration"`
+	Last4      string         `json:"last4"`
+}
+
+type cardExpiration struct {
+	Month int64 `json:"month"`
+	Year  int64 `json:"year"`
+}
+
+type cart struct {
+	Items     []cartItem   `json:"items"`
+	Subtotal  int64        `json:"subtotal"`
+	AddressID string       `json:"addressID,omitempty"`
+	CardID    string       `json:"cardID,omitempty"`
+	Amount    cartAmount   `json:"amount"`
+	Shipping  cartShipping `json:"shipping"`
+}
+
+type cartItem struct {
+	ID               string `json:"id"`
+	ProductVariantID string `json:"productVariantID"`
+	Quantity         int64  `json:"quantity"`
+	Subtotal         int64  `json:"subtotal"`
+}
+
+type cartAmount struct {
+	Subtotal int64 `json:"subtotal"`
+	Shipping int64 `json:"shipping"`
+	Total    int64 `json:"total"`
+}
+
+type cartShipping struct {
+	Service   string `json:"service,omitempty"`
+	Timeframe string `json:"timeframe,omitempty"`
+}
+
+type order struct {
+	ID       string        `json:"id"`
+	Index    int64         `json:"index"`
+	Created  string        `json:"created"`
+	Amount   orderAmount   `json:"amount"`
+	Items    []orderItem   `json:"items"`
+	Shipping orderShipping `json:"shipping"`
+	Tracking orderTracking `json:"tracking"`
+}
+
+type orderAmount struct {
+	Subtotal int64 `json:"subtotal"`
+	Shipping int64 `json:"shipping"`
+}
+
+type orderItem struct {
+	ID               string `json:"id"`
+	Amount           int64  `json:"amount"`
+	Quantity         int64  `json:"quantity"`
+	Description      string `json:"description,omitempty"`
+	ProductVariantID string `json:"productVariantID,omitempty"`
+}
+
+type orderShipping struct {
+	Name     string `json:"name"`
+	Street1  string `json:"street1"`
+	Street2  string `json:"street2,omitempty"`
+	City     string `json:"city"`
+	Province string `json:"province,omitempty"`
+	Zip      string `json:"zip"`
+	Country  string `json:"country"`
+	Phone    string `json:"phone,omitempty"`
+}
+
+type orderTracking struct {
+	Number  string `json:"number,omitempty"`
+	Service string `json:"service,omitempty"`
+	URL     string `json:"url,omitempty"`
+	// Status is the carrier's tracking status, which the SDK doesn't model
+	Status string `json:"status,omitempty"`
+}
+
+type subscription struct {
+	ID               string               `json:"id"`
+	AddressID        string               `json:"addressID"`
+	CardID           string               `json:"cardID"`
+	ProductVariantID string               `json:"productVariantID"`
+	Quantity         int64                `json:"quantity"`
+	Next             string               `json:"next,omitempty"`
+	Schedule         subscriptionSchedule `json:"schedule"`
+}
+
+type subscriptionSchedule struct {
+	Type     string `json:"type"`
+	Interval int64  `json:"interval,omitempty"`
+}
+
+type token struct {
+	ID      string `json:"id"`
+	Token   string `json:"token"`
+	Created string `json:"created"`
+}
+
+type app struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	RedirectURI string `json:"redirectURI"`
+	Secret      string `json:"secret"`
+}
@@ -0,0 +1,188 @@
+// Package mockapi provides an in-memory fake of the Terminal Shop REST API.
+//
+// It is meant for tests and local development: point NewClient (or the
+// provider's api_endpoint) at the server URL and every call is served from
+// memory, without network access or a real account.
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultToken is the bearer token accepted by an API created with New
+const DefaultToken = "trm_test_mock"
+
+// API is an in-memory fake of the Terminal REST API. It implements
+// http.Handler so it can be served by httptest or a real listener.
+type API struct {
+	mu sync.Mutex
+
+	// token is the bearer token accepted in addition to minted tokens
+	token string
+	// now returns the current time, replaceable for deterministic tests
+	now func() time.Time
+
+	seq           int
+	products      []*product
+	profile       *profile
+	addresses     map[string]*address
+	cards         map[string]*card
+	cart          *cart
+	orders        []*order
+	subscriptions map[string]*subscription
+	tokens        map[string]*token
+	apps          map[string]*app
+}
+
+// New returns an API seeded with the product catalog and an empty account
+// that accepts DefaultToken
+func New() *API {
+	a := &API{
+		token:         DefaultToken,
+		now:           time.Now,
+		products:      catalog(),
+		addresses:     make(map[string]*address),
+		cards:         make(map[string]*card),
+		cart:          newCart(),
+		orders:        []*order{},
+		subscriptions: make(map[string]*subscription),
+		tokens:        make(map[string]*token),
+		apps:          make(map[string]*app),
+	}
+	a.profile = &profile{
+		ID:               a.newID("usr"),
+		Name:             "Mock User",
+		Email:            "mock@example.com",
+		Fingerprint:      "mock-fingerprint",
+		StripeCustomerID: "cus_mock",
+	}
+
+	return a
+}
+
+// Server is an httptest.Server backed by an API
+type Server struct {
+	*httptest.Server
+	API *API
+}
+
+// NewServer starts a local server backed by a fresh API. Callers should call
+// Close when finished.
+func NewServer() *Server {
+	api := New()
+
+	return &Server{
+		Server: httptest.NewServer(api),
+		API:    api,
+	}
+}
+
+// SetToken changes the static bearer token accepted by the API
+func (a *API) SetToken(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.token = token
+}
+
+// newID generates an object identifier with the given prefix. Callers must
+// hold the lock.
+func (a *API) newID(prefix string) string {
+	a.seq++
+	return fmt.Sprintf("%s_MOCK%021d", prefix, a.seq)
+}
+
+// ServeHTTP routes a request to the matching resource handler
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid or missing bearer token")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	resource, rest := segments[0], segments[1:]
+
+	switch resource {
+	case "product":
+		a.serveProduct(w, r, rest)
+	case "profile":
+		a.serveProfile(w, r, rest)
+	case "address":
+		a.serveAddress(w, r, rest)
+	case "card":
+		a.serveCard(w, r, rest)
+	case "cart":
+		a.serveCart(w, r, rest)
+	case "order":
+		a.serveOrder(w, r, rest)
+	case "subscription":
+		a.serveSubscription(w, r, rest)
+	case "token":
+		a.serveToken(w, r, rest)
+	case "app":
+		a.serveApp(w, r, rest)
+	default:
+		writeNotFound(w)
+	}
+}
+
+// authorized reports whether the request carries an accepted bearer token
+func (a *API) authorized(r *http.Request) bool {
+	bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if bearer == "" {
+		return false
+	}
+	if bearer == a.token {
+		return true
+	}
+	for _, t := range a.tokens {
+		if t.Token == bearer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeData writes a successful response in the API's {"data": ...} envelope
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+// writeError writes an error response with the given status code
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"code":    code,
+		"message": message,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "not_found", "resource not found")
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed")
+}
+
+// decode reads a JSON request body, writing a validation error on failure
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "validation", fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+
+	return true
+}
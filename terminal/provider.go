@@ -2,69 +2,352 @@ package terminal
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// Provider returns a terraform.ResourceProvider.
-func Provider() *schema.Provider {
-	return &schema.Provider{
-		Schema: map[string]*schema.Schema{
-			"api_endpoint": {
-				Type:        schema.TypeString,
+const (
+	defaultAPIEndpoint = "https://api.terminal.shop"
+	devAPIEndpoint     = "https://api.dev.terminal.shop"
+)
+
+var (
+	_ provider.Provider                       = &terminalProvider{}
+	_ provider.ProviderWithFunctions          = &terminalProvider{}
+	_ provider.ProviderWithEphemeralResources = &terminalProvider{}
+)
+
+// terminalProvider is the Terminal Shop provider
+type terminalProvider struct {
+	// version is the provider release version, or "dev" for local builds
+	version string
+}
+
+// terminalProviderModel maps the provider block
+type terminalProviderModel struct {
+	APIEndpoint       types.String  `tfsdk:"api_endpoint"`
+	UseDevEnvironment types.Bool    `tfsdk:"use_dev_environment"`
+	APIToken          types.String  `tfsdk:"api_token"`
+	MaxOrderTotal     types.Float64 `tfsdk:"max_order_total"`
+	MaxItemsPerOrder  types.Int64   `tfsdk:"max_items_per_order"`
+	Budget            *budgetModel  `tfsdk:"budget"`
+	MaxRetries        types.Int64   `tfsdk:"max_retries"`
+	RetryMinWait      types.String  `tfsdk:"retry_min_wait"`
+	RetryMaxWait      types.String  `tfsdk:"retry_max_wait"`
+}
+
+// budgetModel maps the provider's budget block
+type budgetModel struct {
+	Period types.String  `tfsdk:"period"`
+	Limit  types.Float64 `tfsdk:"limit"`
+}
+
+// New returns a constructor for the provider, as expected by providerserver
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &terminalProvider{
+			version: version,
+		}
+	}
+}
+
+func (p *terminalProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "terminal"
+	resp.Version = p.version
+}
+
+func (p *terminalProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_endpoint": schema.StringAttribute{
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("TERMINAL_API_ENDPOINT", "https://api.terminal.shop"),
-				Description: "The Terminal Shop API endpoint (use https://api.dev.terminal.shop for development/testing)",
+				Description: "The Terminal Shop API endpoint (use https://api.dev.terminal.shop for development/testing). Defaults to TERMINAL_API_ENDPOINT or https://api.terminal.shop",
 			},
-			"use_dev_environment": {
-				Type:        schema.TypeBool,
+			"use_dev_environment": schema.BoolAttribute{
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("TERMINAL_USE_DEV", false),
-				Description: "Set to true to use the Terminal Shop development environment (overrides api_endpoint)",
+				Description: "Set to true to use the Terminal Shop development environment (overrides api_endpoint). Defaults to TERMINAL_USE_DEV",
 			},
-			"api_token": {
-				Type:        schema.TypeString,
-				Required:    true,
+			"api_token": schema.StringAttribute{
+				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("TERMINAL_API_TOKEN", nil),
-				Description: "The API token for Terminal Shop authentication",
+				Description: "The API token for Terminal Shop authentication. Defaults to TERMINAL_API_TOKEN",
+			},
+			"max_order_total": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The most a single coffee order may cost in dollars, including shipping. Coffee orders are checked against their estimated_subtotal_cents and estimated_shipping_cents, with shipping counted as free when it can't be estimated; carts are checked against the API's total. Orders over the limit fail before they are placed",
+				Validators:  []validator.Float64{float64validator.AtLeast(0)},
+			},
+			"max_items_per_order": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The most units a single coffee order may contain. Orders over the limit fail before they are placed",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "How many times to retry a request that is rate limited or hits a transient server error. Requests that aren't idempotent, such as placing an order, are never retried. Defaults to 3",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"retry_min_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "The wait before the first retry, doubling for each retry after it. Defaults to 1s",
+				Validators:  []validator.String{validDuration()},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "The longest wait between retries. Requests the API asks to retry later than this, through Retry-After, fail instead. Defaults to 30s",
+				Validators:  []validator.String{validDuration()},
 			},
 		},
-		ResourcesMap: map[string]*schema.Resource{
-			"terminal_address":      resourceAddress(),
-			"terminal_payment_card": resourceCard(),
-			"terminal_coffee_order": resourceOrder(),
-		},
-		DataSourcesMap: map[string]*schema.Resource{
-			"terminal_address":      dataSourceAddress(),
-			"terminal_payment_card": dataSourceCard(),
-			"terminal_coffee_order": dataSourceOrder(),
+		Blocks: map[string]schema.Block{
+			"budget": schema.SingleNestedBlock{
+				Description: "Caps the spend across all orders on the account in the current calendar period, including shipping. Orders that would exceed it fail before they are placed. Orders are dated by the API's created field, which the Terminal SDK doesn't model; if an order that may be in the period comes without it, placing orders fails",
+				Attributes: map[string]schema.Attribute{
+					"period": schema.StringAttribute{
+						Optional:    true,
+						Description: "The calendar period the budget covers, in UTC: day, week (from Monday), month or year",
+						Validators:  []validator.String{stringvalidator.OneOf(budgetPeriods...)},
+					},
+					"limit": schema.Float64Attribute{
+						Optional:    true,
+						Description: "The most that may be spent in the period, in dollars",
+						Validators:  []validator.Float64{float64validator.AtLeast(0)},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("period"),
+						path.MatchRelative().AtName("limit"),
+					),
+				},
+			},
 		},
-		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-// providerConfigure creates and returns a client configuration
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	apiEndpoint := d.Get("api_endpoint").(string)
-	apiToken := d.Get("api_token").(string)
-	useDev := d.Get("use_dev_environment").(bool)
+func (p *terminalProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config terminalProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := configureClient(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
 
-	// Warning or errors can be collected in a slice
+// configureClient creates the API client for the provider configuration,
+// falling back to environment variables for unset attributes
+func configureClient(config terminalProviderModel) (*SDKClient, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	// If use_dev_environment is set, override the endpoint
-	// We'll use a special value to indicate we want the dev environment
-	// This will be handled in the client with WithEnvironmentDev()
-	if useDev {
-		apiEndpoint = "https://api.dev.terminal.shop"
+	for _, a := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"api_endpoint", config.APIEndpoint},
+		{"use_dev_environment", config.UseDevEnvironment},
+		{"api_token", config.APIToken},
+		{"max_order_total", config.MaxOrderTotal},
+		{"max_items_per_order", config.MaxItemsPerOrder},
+		{"max_retries", config.MaxRetries},
+		{"retry_min_wait", config.RetryMinWait},
+		{"retry_max_wait", config.RetryMaxWait},
+	} {
+		if a.value.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root(a.name),
+				"Unknown provider configuration",
+				"The provider can't be configured with a value that is only known after apply. Set "+a.name+" statically or through the environment.",
+			)
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	apiEndpoint := os.Getenv("TERMINAL_API_ENDPOINT")
+	if apiEndpoint == "" {
+		apiEndpoint = defaultAPIEndpoint
+	}
+	if !config.APIEndpoint.IsNull() {
+		apiEndpoint = config.APIEndpoint.ValueString()
+	}
+
+	useDev, _ := strconv.ParseBool(os.Getenv("TERMINAL_USE_DEV"))
+	if !config.UseDevEnvironment.IsNull() {
+		useDev = config.UseDevEnvironment.ValueBool()
 	}
 
-	client, err := NewClient(apiEndpoint, apiToken)
+	apiToken := os.Getenv("TERMINAL_API_TOKEN")
+	if !config.APIToken.IsNull() {
+		apiToken = config.APIToken.ValueString()
+	}
+	if apiToken == "" {
+		diags.AddAttributeError(
+			path.Root("api_token"),
+			"Missing Terminal API token",
+			"Set api_token in the provider block or the TERMINAL_API_TOKEN environment variable.",
+		)
+		return nil, diags
+	}
+
+	client, err := NewClient(resolveAPIEndpoint(apiEndpoint, useDev), apiToken)
 	if err != nil {
-		return nil, diag.FromErr(err)
+		diags.AddError("Unable to create Terminal client", err.Error())
+		return nil, diags
+	}
+
+	if !config.MaxRetries.IsNull() {
+		client.Retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	// The validators have already checked the durations parse
+	if !config.RetryMinWait.IsNull() {
+		client.Retry.MinWait, _ = time.ParseDuration(config.RetryMinWait.ValueString())
+	}
+	if !config.RetryMaxWait.IsNull() {
+		client.Retry.MaxWait, _ = time.ParseDuration(config.RetryMaxWait.ValueString())
+	}
+	if client.Retry.MinWait > client.Retry.MaxWait {
+		diags.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Invalid retry configuration",
+			fmt.Sprintf("retry_min_wait (%s) can't be longer than retry_max_wait (%s).", client.Retry.MinWait, client.Retry.MaxWait),
+		)
+		return nil, diags
+	}
+
+	client.Limits = orderLimits{
+		MaxItemsPerOrder: config.MaxItemsPerOrder.ValueInt64(),
+	}
+	if !config.MaxOrderTotal.IsNull() {
+		client.Limits.MaxOrderTotal = dollarsToCents(config.MaxOrderTotal.ValueFloat64())
+	}
+	if config.Budget != nil {
+		if config.Budget.Period.IsUnknown() || config.Budget.Limit.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root("budget"),
+				"Unknown provider configuration",
+				"The provider can't be configured with a value that is only known after apply. Set budget statically.",
+			)
+			return nil, diags
+		}
+
+		client.Limits.Budget = &orderBudget{
+			Period: config.Budget.Period.ValueString(),
+			Limit:  dollarsToCents(config.Budget.Limit.ValueFloat64()),
+		}
 	}
 
 	return client, diags
-}
\ No newline at end of file
+}
+
+// resolveAPIEndpoint returns the endpoint the provider should talk to.
+// use_dev_environment takes precedence over any configured api_endpoint.
+func resolveAPIEndpoint(apiEndpoint string, useDev bool) string {
+	if useDev {
+		return devAPIEndpoint
+	}
+
+	return apiEndpoint
+}
+
+// clientFromProviderData returns the client the provider passed to a resource
+// or data source. It is nil until the provider has been configured.
+func clientFromProviderData(providerData any, diags *diag.Diagnostics) *SDKClient {
+	if providerData == nil {
+		return nil
+	}
+
+	client, ok := providerData.(*SDKClient)
+	if !ok {
+		diags.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *SDKClient, got %T. Please report this issue to the provider developers.", providerData),
+		)
+		return nil
+	}
+
+	return client
+}
+
+func (p *terminalProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		NewAddressResource,
+		NewAppResource,
+		NewCardResource,
+		NewCartResource,
+		NewOrderResource,
+		NewProfileResource,
+		NewSubscriptionResource,
+		NewTokenResource,
+	}
+}
+
+func (p *terminalProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewAddressDataSource,
+		NewAddressesDataSource,
+		NewAppsDataSource,
+		NewCardDataSource,
+		NewCardsDataSource,
+		NewOrderDataSource,
+		NewOrdersDataSource,
+		NewProductsDataSource,
+		NewProductDataSource,
+		NewProfileDataSource,
+	}
+}
+
+// Ephemeral resources are available from Terraform 1.10. Their values are
+// never written to state or plan files.
+func (p *terminalProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewCardCollectionEphemeralResource,
+		NewTokenEphemeralResource,
+	}
+}
+
+// Functions are available from Terraform 1.8, as provider::<name>::<function>
+func (p *terminalProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewCupsFunction,
+		NewOrderTotalFunction,
+		NewParseIDFunction,
+	}
+}
+
+// optionalString maps an empty API value to null, matching an unset optional
+// attribute in configuration
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
@@ -0,0 +1,292 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal/mockapi"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testAccProtoV6ProviderFactories registers the provider under the
+// "terminal" name so that terminal_* resource types resolve to it without
+// explicit provider arguments
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"terminal": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+func TestProvider(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["terminal"]()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Building the schemas runs the framework's own validation of every
+	// resource and data source
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+// testAccSetup returns the provider block and a client for the API that an
+// acceptance test runs against. Tests use the dev environment when
+// TEST_TERMINAL_API_TOKEN is set and a fresh mock API otherwise, so the suite
+// works without network access.
+func testAccSetup(t *testing.T) (string, *SDKClient) {
+	if apiToken := os.Getenv("TEST_TERMINAL_API_TOKEN"); apiToken != "" {
+		client, err := NewClient("https://api.dev.terminal.shop", apiToken)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		return fmt.Sprintf(`
+provider "terminal" {
+  api_token           = %q
+  use_dev_environment = true
+}
+`, apiToken), client
+	}
+
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	return fmt.Sprintf(`
+provider "terminal" {
+  api_endpoint = %q
+  api_token    = %q
+}
+`, server.URL, mockapi.DefaultToken), client
+}
+
+// testAccStripeToken returns the Stripe test token used to create cards
+func testAccStripeToken() string {
+	if token := os.Getenv("TEST_STRIPE_TOKEN"); token != "" {
+		return token
+	}
+	return "tok_visa"
+}
+
+// testAccVariantID returns the product variant ordered by acceptance tests
+func testAccVariantID() string {
+	if variantID := os.Getenv("TEST_VARIANT_ID"); variantID != "" {
+		return variantID
+	}
+	return "var_9U04ZMMHXK"
+}
+
+// testUpgradeState runs raw state written at the given schema version through
+// the provider's state upgraders and returns the upgraded attributes
+func testUpgradeState(t *testing.T, typeName string, version int64, rawState string) map[string]tftypes.Value {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["terminal"]()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s, ok := schemas.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for %s", typeName)
+	}
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(rawState)},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	value, err := resp.UpgradedState.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return attributes
+}
+
+// testAccFlakySetup is like testAccSetup for a mock API whose requests from
+// the provider are first passed to fail, which can write an error response
+// instead, after passing the request on to the mock API if it likes. Retries
+// are disabled, and the returned client bypasses fail.
+func testAccFlakySetup(t *testing.T, fail func(w http.ResponseWriter, r *http.Request, api http.Handler) bool) (string, *SDKClient) {
+	api := mockapi.New()
+
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !fail(w, r, api) {
+			api.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(flaky.Close)
+
+	direct := httptest.NewServer(api)
+	t.Cleanup(direct.Close)
+
+	client, err := NewClient(direct.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	return fmt.Sprintf(`
+provider "terminal" {
+  api_endpoint = %q
+  api_token    = %q
+  max_retries  = 0
+}
+`, flaky.URL, mockapi.DefaultToken), client
+}
+
+// testOpenEphemeralResource configures the provider against a fresh mock API
+// and opens an ephemeral resource with an empty configuration. It returns the
+// result attributes, a function that closes the resource, and a client for
+// the mock API.
+func testOpenEphemeralResource(t *testing.T, typeName string) (map[string]tftypes.Value, func(), *SDKClient) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	mock := mockapi.NewServer()
+	t.Cleanup(mock.Close)
+
+	client, err := NewClient(mock.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	server, schemas := testConfigureProvider(t, mock.URL)
+	s, ok := schemas.EphemeralResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no schema for %s", typeName)
+	}
+
+	openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: typeName,
+		Config:   testNullObject(t, s.ValueType(), nil),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testCheckDiagnostics(t, openResp.Diagnostics)
+
+	value, err := openResp.Result.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	closeResource := func() {
+		closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+			TypeName: typeName,
+			Private:  openResp.Private,
+		})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		testCheckDiagnostics(t, closeResp.Diagnostics)
+	}
+
+	return attributes, closeResource, client
+}
+
+// testConfigureProvider returns a provider server configured against the
+// given endpoint with retries disabled, along with its schemas
+func testConfigureProvider(t *testing.T, endpoint string) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["terminal"]()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	providerConfig := testNullObject(t, schemas.Provider.ValueType(), map[string]tftypes.Value{
+		"api_endpoint": tftypes.NewValue(tftypes.String, endpoint),
+		"api_token":    tftypes.NewValue(tftypes.String, mockapi.DefaultToken),
+		"max_retries":  tftypes.NewValue(tftypes.Number, big.NewFloat(0)),
+	})
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: providerConfig})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testCheckDiagnostics(t, configureResp.Diagnostics)
+
+	return server, schemas
+}
+
+// testNullObject returns an object of the given type as a DynamicValue, with
+// the given attributes set and every other attribute null
+func testNullObject(t *testing.T, typ tftypes.Type, attributes map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	objectType := typ.(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return &dv
+}
+
+// testCheckDiagnostics fails the test on any error diagnostic
+func testCheckDiagnostics(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+}
@@ -2,127 +2,269 @@ package terminal
 
 import (
 	"context"
+	"errors"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-func resourceAddress() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceAddressCreate,
-		ReadContext:   resourceAddressRead,
-		DeleteContext: resourceAddressDelete, // No-op but needed for resource requirements
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The name associated with the address",
+var (
+	_ resource.ResourceWithConfigure      = &addressResource{}
+	_ resource.ResourceWithImportState    = &addressResource{}
+	_ resource.ResourceWithValidateConfig = &addressResource{}
+)
+
+// addressResource manages a saved shipping address
+type addressResource struct {
+	client *SDKClient
+}
+
+type addressResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	Name            types.String   `tfsdk:"name"`
+	Street1         types.String   `tfsdk:"street1"`
+	Street2         types.String   `tfsdk:"street2"`
+	City            types.String   `tfsdk:"city"`
+	State           types.String   `tfsdk:"state"`
+	Zip             types.String   `tfsdk:"zip"`
+	Country         types.String   `tfsdk:"country"`
+	Phone           types.String   `tfsdk:"phone"`
+	RetainOnDestroy types.Bool     `tfsdk:"retain_on_destroy"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewAddressResource() resource.Resource {
+	return &addressResource{}
+}
+
+func (r *addressResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_address"
+}
+
+func (r *addressResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Differences in case and whitespace are stored in place instead of
+	// replacing the address
+	textReplace := requiresReplaceUnlessNormalized(normalizeAddressText)
+	codeReplace := requiresReplaceUnlessNormalized(normalizeAddressCode)
+
+	resp.Schema = schema.Schema{
+		Description: "A saved shipping address",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of the address",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"street1": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The first line of the street address",
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name associated with the address",
+				PlanModifiers: textReplace,
 			},
-			"street2": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "The second line of the street address",
+			"street1": schema.StringAttribute{
+				Required:      true,
+				Description:   "The first line of the street address",
+				PlanModifiers: textReplace,
 			},
-			"city": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The city name",
+			"street2": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The second line of the street address",
+				PlanModifiers: textReplace,
 			},
-			"state": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "The state or province",
+			"city": schema.StringAttribute{
+				Required:      true,
+				Description:   "The city name",
+				PlanModifiers: textReplace,
+			},
+			"state": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The state or province. Required in countries such as the US, Canada and Australia",
+				PlanModifiers: textReplace,
+			},
+			"zip": schema.StringAttribute{
+				Required:      true,
+				Description:   "The zip or postal code, checked against the country's format where known (e.g., 94103-1234 in the US, SW1A 1AA in the UK)",
+				PlanModifiers: codeReplace,
 			},
-			"zip": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The zip or postal code",
+			"country": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ISO 3166-1 alpha-2 country code (e.g., US)",
+				PlanModifiers: codeReplace,
+				Validators:    []validator.String{validCountryCode()},
 			},
-			"country": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The country code (e.g., US)",
+			"phone": schema.StringAttribute{
+				Optional:      true,
+				Description:   "The recipient's phone number in E.164 format (e.g., +14155550123), used by couriers. Spaces, dashes, dots and parentheses are ignored",
+				PlanModifiers: requiresReplaceUnlessNormalized(normalizePhoneNumber),
+				Validators:    []validator.String{validPhoneNumber()},
+			},
+			"retain_on_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Set to true to keep the address in Terminal Shop when it is destroyed, only removing it from Terraform state",
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(5 * time.Minute),
-			Read:   schema.DefaultTimeout(5 * time.Minute),
-			Delete: schema.DefaultTimeout(5 * time.Minute),
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+func (r *addressResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
 
-	address := &Address{
-		Name:    d.Get("name").(string),
-		Street1: d.Get("street1").(string),
-		City:    d.Get("city").(string),
-		Zip:     d.Get("zip").(string),
-		Country: d.Get("country").(string),
+// ValidateConfig checks the postal code and state against the country, so
+// mistakes are caught at plan time rather than by the API mid-apply
+func (r *addressResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config addressResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, problem := range validateAddress(config.Country, config.Zip, config.State) {
+		resp.Diagnostics.AddAttributeError(path.Root(problem.Attribute), problem.Summary, problem.Detail)
+	}
+}
+
+func (r *addressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan addressResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Add optional fields if they exist
-	if v, ok := d.GetOk("street2"); ok {
-		address.Street2 = v.(string)
+	timeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if v, ok := d.GetOk("state"); ok {
-		address.State = v.(string)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The API gets normalized values, while state keeps them as configured
+	address := &Address{
+		Name:    normalizeAddressText(plan.Name.ValueString()),
+		Street1: normalizeAddressText(plan.Street1.ValueString()),
+		Street2: normalizeAddressText(plan.Street2.ValueString()),
+		City:    normalizeAddressText(plan.City.ValueString()),
+		State:   normalizeAddressText(plan.State.ValueString()),
+		Zip:     normalizeAddressCode(plan.Zip.ValueString()),
+		Country: normalizeAddressCode(plan.Country.ValueString()),
+		Phone:   normalizePhoneNumber(plan.Phone.ValueString()),
 	}
 
-	createdAddress, err := client.CreateAddress(ctx, address)
+	createdAddress, err := r.client.CreateAddress(ctx, address)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error creating address", err.Error())
+		return
 	}
 
-	d.SetId(createdAddress.ID)
+	plan.ID = types.StringValue(createdAddress.ID)
 
-	return resourceAddressRead(ctx, d, m)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+func (r *addressResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state addressResourceModel
 
-	var diags diag.Diagnostics
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	addressID := d.Id()
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	address, err := client.GetAddress(ctx, addressID)
+	address, err := r.client.GetAddress(ctx, state.ID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		// The address was removed outside of Terraform, so drop it from state
+		// and let the next plan propose recreating it
+		tflog.Warn(ctx, "Address not found, removing from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading address", err.Error())
+		return
 	}
 
-	d.Set("name", address.Name)
-	d.Set("street1", address.Street1)
-	d.Set("street2", address.Street2)
-	d.Set("city", address.City)
-	d.Set("state", address.State)
-	d.Set("zip", address.Zip)
-	d.Set("country", address.Country)
+	state.Name = normalizedString(state.Name, address.Name, normalizeAddressText)
+	state.Street1 = normalizedString(state.Street1, address.Street1, normalizeAddressText)
+	state.Street2 = normalizedString(state.Street2, address.Street2, normalizeAddressText)
+	state.City = normalizedString(state.City, address.City, normalizeAddressText)
+	state.State = normalizedString(state.State, address.State, normalizeAddressText)
+	state.Zip = normalizedString(state.Zip, address.Zip, normalizeAddressCode)
+	state.Country = normalizedString(state.Country, address.Country, normalizeAddressCode)
+	state.Phone = normalizedString(state.Phone, address.Phone, normalizePhoneNumber)
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func resourceAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (r *addressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan addressResourceModel
+
+	// Only retain_on_destroy and differences in case or whitespace can change
+	// in place, and neither is sent to the API
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-	// Terminal Shop API doesn't support deleting addresses, so this is a no-op
-	// We just forget about the resource from Terraform's perspective
-	d.SetId("")
+func (r *addressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state addressResourceModel
 
-	return diags
-}
\ No newline at end of file
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.RetainOnDestroy.ValueBool() {
+		tflog.Info(ctx, "retain_on_destroy is set, removing address from state only", map[string]interface{}{"id": state.ID.ValueString()})
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.client.DeleteAddress(ctx, state.ID.ValueString()); err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Error deleting address", err.Error())
+	}
+}
+
+func (r *addressResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Imported addresses get the schema default so the first plan is clean
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("retain_on_destroy"), false)...)
+}
@@ -0,0 +1,305 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAddress_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var address Address
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAddressDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAddressConfig(providerConfig, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAddressExists(client, "terminal_address.test", &address),
+					resource.TestCheckResourceAttr("terminal_address.test", "name", "Test User"),
+					resource.TestCheckResourceAttr("terminal_address.test", "street1", "123 Test St"),
+					resource.TestCheckResourceAttr("terminal_address.test", "street2", "Suite 1"),
+					resource.TestCheckResourceAttr("terminal_address.test", "city", "Test City"),
+					resource.TestCheckResourceAttr("terminal_address.test", "state", "CA"),
+					resource.TestCheckResourceAttr("terminal_address.test", "zip", "12345"),
+					resource.TestCheckResourceAttr("terminal_address.test", "country", "US"),
+					resource.TestCheckResourceAttr("terminal_address.test", "retain_on_destroy", "false"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terminal_address.test", "name", "Test User"),
+				),
+			},
+			{
+				ResourceName:      "terminal_address.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAddress_disappears(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var address Address
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAddressDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAddressConfig(providerConfig, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAddressExists(client, "terminal_address.test", &address),
+					testAccCheckAddressDisappears(client, &address),
+				),
+				// Terraform should notice the address is gone and plan to recreate it
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAddress_retainOnDestroy(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var address Address
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := client.GetAddress(context.Background(), address.ID); err != nil {
+				return fmt.Errorf("address %s should have been retained: %v", address.ID, err)
+			}
+			return client.DeleteAddress(context.Background(), address.ID)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAddressConfig(providerConfig, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAddressExists(client, "terminal_address.test", &address),
+					resource.TestCheckResourceAttr("terminal_address.test", "retain_on_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAddress_validation(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAddressLocationConfig(providerConfig, "CA", "1234", "US"),
+				ExpectError: regexp.MustCompile(`Invalid postal code`),
+			},
+			{
+				Config:      testAccAddressLocationConfig(providerConfig, "CA", "12345", "XX"),
+				ExpectError: regexp.MustCompile(`Invalid country code`),
+			},
+			{
+				Config:      testAccAddressLocationConfig(providerConfig, "", "K1A 0B1", "CA"),
+				ExpectError: regexp.MustCompile(`Missing state`),
+			},
+		},
+	})
+}
+
+func TestAccAddress_normalization(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var address Address
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAddressDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAddressLocationConfig(providerConfig, "ON", " k1a  0b1 ", "ca"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAddressExists(client, "terminal_address.test", &address),
+					resource.TestCheckResourceAttr("terminal_address.test", "zip", " k1a  0b1 "),
+					resource.TestCheckResourceAttr("terminal_address.test", "country", "ca"),
+					func(s *terraform.State) error {
+						if address.Zip != "K1A 0B1" || address.Country != "CA" {
+							return fmt.Errorf("expected the API to get K1A 0B1, CA, got %s, %s", address.Zip, address.Country)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccAddressLocationConfig(providerConfig, "ON", "K1A 0B1", "CA"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terminal_address.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("terminal_address.test", "id", &address.ID),
+					resource.TestCheckResourceAttr("terminal_address.test", "zip", "K1A 0B1"),
+				),
+			},
+			{
+				Config: testAccAddressLocationConfig(providerConfig, "ON", "K1A 0B2", "CA"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terminal_address.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccAddress_phone(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var address Address
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAddressDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAddressPhoneConfig(providerConfig, "555-0123"),
+				ExpectError: regexp.MustCompile(`Invalid phone number`),
+			},
+			{
+				Config: testAccAddressPhoneConfig(providerConfig, "+1 (415) 555-0123"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAddressExists(client, "terminal_address.test", &address),
+					resource.TestCheckResourceAttr("terminal_address.test", "phone", "+1 (415) 555-0123"),
+					resource.TestCheckResourceAttr("data.terminal_address.test", "phone", "+14155550123"),
+					func(s *terraform.State) error {
+						if address.Phone != "+14155550123" {
+							return fmt.Errorf("expected the API to get +14155550123, got %s", address.Phone)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccAddressPhoneConfig(providerConfig, "+14155550123"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terminal_address.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: testAccAddressPhoneConfig(providerConfig, "+442079460958"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terminal_address.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("data.terminal_address.test", "phone", "+442079460958"),
+			},
+		},
+	})
+}
+
+func testAccCheckAddressExists(client *SDKClient, name string, address *Address) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+
+		found, err := client.GetAddress(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*address = *found
+		return nil
+	}
+}
+
+func testAccCheckAddressDisappears(client *SDKClient, address *Address) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		return client.DeleteAddress(context.Background(), address.ID)
+	}
+}
+
+func testAccCheckAddressDestroy(client *SDKClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "terminal_address" {
+				continue
+			}
+
+			_, err := client.GetAddress(context.Background(), rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("address %s still exists", rs.Primary.ID)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccAddressConfig(providerConfig string, retainOnDestroy bool) string {
+	return providerConfig + fmt.Sprintf(`
+resource "terminal_address" "test" {
+  name    = "Test User"
+  street1 = "123 Test St"
+  street2 = "Suite 1"
+  city    = "Test City"
+  state   = "CA"
+  zip     = "12345"
+  country = "US"
+
+  retain_on_destroy = %t
+}
+`, retainOnDestroy)
+}
+
+func testAccAddressLocationConfig(providerConfig, state, zip, country string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "terminal_address" "test" {
+  name    = "Test User"
+  street1 = "123 Test St"
+  city    = "Test City"
+  state   = %q
+  zip     = %q
+  country = %q
+}
+`, state, zip, country)
+}
+
+func testAccAddressPhoneConfig(providerConfig, phone string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "terminal_address" "test" {
+  name    = "Reception"
+  street1 = "123 Test St"
+  city    = "Test City"
+  state   = "CA"
+  zip     = "12345"
+  country = "US"
+  phone   = %q
+}
+
+data "terminal_address" "test" {
+  address_id = terminal_address.test.id
+}
+`, phone)
+}
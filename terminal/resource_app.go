@@ -0,0 +1,211 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &appResource{}
+	_ resource.ResourceWithImportState = &appResource{}
+)
+
+// redirectURIPattern matches an absolute URI, including the custom schemes
+// used by native apps
+var redirectURIPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*://\S+$`)
+
+// appResource registers an OAuth app. Apps can't be changed once registered,
+// so every argument forces a new app, and the client secret is only revealed
+// when it is created.
+type appResource struct {
+	client *SDKClient
+}
+
+type appResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	RedirectURI  types.String   `tfsdk:"redirect_uri"`
+	ClientID     types.String   `tfsdk:"client_id"`
+	ClientSecret types.String   `tfsdk:"client_secret"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewAppResource() resource.Resource {
+	return &appResource{}
+}
+
+func (r *appResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app"
+}
+
+func (r *appResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	useState := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+
+	resp.Schema = schema.Schema{
+		Description: "An OAuth 2.0 app for signing in to Terminal on behalf of users",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of the app",
+				PlanModifiers: useState,
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the app, shown to users when they authorize it",
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"redirect_uri": schema.StringAttribute{
+				Required:      true,
+				Description:   "The URI users are sent back to after authorizing the app",
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{stringvalidator.RegexMatches(redirectURIPattern, "must be an absolute URI")},
+			},
+			"client_id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The OAuth client ID",
+				PlanModifiers: useState,
+			},
+			"client_secret": schema.StringAttribute{
+				Computed:      true,
+				Sensitive:     true,
+				Description:   "The OAuth client secret. Only known for apps created by Terraform, imported apps leave it null",
+				PlanModifiers: useState,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *appResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan appResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	app, err := r.client.CreateApp(ctx, &App{
+		Name:        plan.Name.ValueString(),
+		RedirectURI: plan.RedirectURI.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating app", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(app.ID)
+	plan.ClientID = types.StringValue(app.ID)
+	plan.ClientSecret = types.StringValue(app.Secret)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state appResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	app, err := r.client.GetApp(ctx, state.ID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "App not found, removing from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading app", err.Error())
+		return
+	}
+
+	// The client secret is masked after creation, so it is kept from state
+	state.Name = types.StringValue(app.Name)
+	state.RedirectURI = types.StringValue(app.RedirectURI)
+	state.ClientID = types.StringValue(app.ID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state appResourceModel
+
+	// Only the timeouts can change in place
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *appResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state appResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.client.DeleteApp(ctx, state.ID.ValueString()); err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Error deleting app", err.Error())
+	}
+}
+
+// ImportState adopts an existing app by ID. Its client secret can't be read
+// back, so client_secret is left null.
+func (r *appResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
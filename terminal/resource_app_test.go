@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccApp_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAppConfig(providerConfig, "not a uri"),
+				ExpectError: regexp.MustCompile(`must be an absolute URI`),
+			},
+			{
+				Config: testAccAppConfig(providerConfig, "https://bot.example.com/callback"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAppExists(client, "terminal_app.test"),
+					resource.TestCheckResourceAttrPair("terminal_app.test", "client_id", "terminal_app.test", "id"),
+					resource.TestCheckResourceAttrSet("terminal_app.test", "client_secret"),
+					resource.TestCheckResourceAttr("terminal_app.test", "redirect_uri", "https://bot.example.com/callback"),
+				),
+			},
+			{
+				ResourceName:      "terminal_app.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The client secret can't be read back
+				ImportStateVerifyIgnore: []string{"client_secret", "timeouts"},
+			},
+			{
+				// Apps can't be changed, so a new redirect URI registers a new app
+				Config: testAccAppConfig(providerConfig, "https://bot.example.com/oauth/callback") + `
+data "terminal_apps" "all" {
+  depends_on = [terminal_app.test]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAppExists(client, "terminal_app.test"),
+					resource.TestCheckResourceAttr("data.terminal_apps.all", "apps.#", "1"),
+					resource.TestCheckResourceAttrPair("data.terminal_apps.all", "apps.0.client_id", "terminal_app.test", "client_id"),
+					resource.TestCheckResourceAttr("data.terminal_apps.all", "apps.0.redirect_uri", "https://bot.example.com/oauth/callback"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAppExists(client *SDKClient, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+
+		_, err := client.GetApp(context.Background(), rs.Primary.ID)
+		return err
+	}
+}
+
+func testAccCheckAppDestroy(client *SDKClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "terminal_app" {
+				continue
+			}
+
+			_, err := client.GetApp(context.Background(), rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("app %s still exists", rs.Primary.ID)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccAppConfig(providerConfig, redirectURI string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "terminal_app" "test" {
+  name         = "Coffee Bot"
+  redirect_uri = %q
+}
+`, redirectURI)
+}
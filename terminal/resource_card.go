@@ -2,97 +2,240 @@ package terminal
 
 import (
 	"context"
+	"errors"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-func resourceCard() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceCardCreate,
-		ReadContext:   resourceCardRead,
-		DeleteContext: resourceCardDelete, // No-op but needed for resource requirements
-		Schema: map[string]*schema.Schema{
-			"token": {
-				Type:        schema.TypeString,
+var (
+	_ resource.ResourceWithConfigure   = &cardResource{}
+	_ resource.ResourceWithImportState = &cardResource{}
+)
+
+// cardResource manages a saved payment card
+type cardResource struct {
+	client *SDKClient
+}
+
+type cardResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	Token           types.String   `tfsdk:"token"`
+	Brand           types.String   `tfsdk:"brand"`
+	Last4           types.String   `tfsdk:"last4"`
+	ExpMonth        types.Int64    `tfsdk:"exp_month"`
+	ExpYear         types.Int64    `tfsdk:"exp_year"`
+	RetainOnDestroy types.Bool     `tfsdk:"retain_on_destroy"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewCardResource() resource.Resource {
+	return &cardResource{}
+}
+
+func (r *cardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_payment_card"
+}
+
+func (r *cardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A saved payment card",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of the payment card",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"token": schema.StringAttribute{
 				Required:    true,
-				ForceNew:    true,
 				Sensitive:   true,
-				Description: "The Stripe token for the payment card",
+				Description: "The Stripe token for the payment card. Tokens can't be read back from the API, so imported cards adopt the configured token without forcing replacement",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							// Imported cards have no token in state
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the token of a card with a known token requires replacement",
+						"Changing the token of a card with a known token requires replacement",
+					),
+				},
 			},
-			"brand": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The card brand (e.g., Visa, Mastercard)",
+			"brand": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The card brand (e.g., Visa, Mastercard)",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"last4": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The last 4 digits of the card number",
+			"last4": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The last 4 digits of the card number",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"exp_month": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "The expiration month (1-12)",
+			"exp_month": schema.Int64Attribute{
+				Computed:      true,
+				Description:   "The expiration month (1-12)",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"exp_year": schema.Int64Attribute{
+				Computed:      true,
+				Description:   "The expiration year",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 			},
-			"exp_year": {
-				Type:        schema.TypeInt,
+			"retain_on_destroy": schema.BoolAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The expiration year",
+				Default:     booldefault.StaticBool(false),
+				Description: "Set to true to keep the payment card in Terminal Shop when it is destroyed, only removing it from Terraform state",
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(5 * time.Minute),
-			Read:   schema.DefaultTimeout(5 * time.Minute),
-			Delete: schema.DefaultTimeout(5 * time.Minute),
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceCardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+func (r *cardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *cardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan cardResourceModel
 
-	card := &Card{
-		Token: d.Get("token").(string),
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	createdCard, err := client.CreateCard(ctx, card)
+	timeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	createdCard, err := r.client.CreateCard(ctx, &Card{Token: plan.Token.ValueString()})
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error creating payment card", err.Error())
+		return
 	}
 
-	d.SetId(createdCard.ID)
+	plan.ID = types.StringValue(createdCard.ID)
 
-	return resourceCardRead(ctx, d, m)
-}
+	// Save the ID straight away so a failed read doesn't orphan the card
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The create response only carries the ID, so fetch the card details
+	card, err := r.client.GetCard(ctx, createdCard.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading payment card", err.Error())
+		return
+	}
+
+	plan.setCard(card)
 
-func resourceCardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-	var diags diag.Diagnostics
+func (r *cardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state cardResourceModel
 
-	cardID := d.Id()
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	card, err := client.GetCard(ctx, cardID)
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	card, err := r.client.GetCard(ctx, state.ID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "Payment card not found, removing from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading payment card", err.Error())
+		return
 	}
 
-	d.Set("brand", card.Brand)
-	d.Set("last4", card.Last4)
-	d.Set("exp_month", card.ExpMonth)
-	d.Set("exp_year", card.ExpYear)
+	state.setCard(card)
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func resourceCardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+// setCard copies the API's view of a card into the model
+func (m *cardResourceModel) setCard(card *Card) {
+	m.Brand = types.StringValue(card.Brand)
+	m.Last4 = types.StringValue(card.Last4)
+	m.ExpMonth = types.Int64Value(int64(card.ExpMonth))
+	m.ExpYear = types.Int64Value(int64(card.ExpYear))
+}
+
+func (r *cardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan cardResourceModel
+
+	// Only retain_on_destroy, or the token of an imported card, can change in
+	// place and neither is sent to the API
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-	// Terminal Shop API doesn't support deleting payment methods, so this is a no-op
-	// We just forget about the resource from Terraform's perspective
-	d.SetId("")
+func (r *cardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state cardResourceModel
 
-	return diags
-}
\ No newline at end of file
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.RetainOnDestroy.ValueBool() {
+		tflog.Info(ctx, "retain_on_destroy is set, removing payment card from state only", map[string]interface{}{"id": state.ID.ValueString()})
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.client.DeleteCard(ctx, state.ID.ValueString()); err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Error deleting payment card", err.Error())
+	}
+}
+
+func (r *cardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Imported payment cards get the schema default so the first plan is clean
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("retain_on_destroy"), false)...)
+}
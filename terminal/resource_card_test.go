@@ -0,0 +1,164 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCard_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var card Card
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCardDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCardConfig(providerConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCardExists(client, "terminal_payment_card.test", &card),
+					resource.TestCheckResourceAttrSet("terminal_payment_card.test", "brand"),
+					resource.TestCheckResourceAttrSet("terminal_payment_card.test", "last4"),
+					resource.TestCheckResourceAttrSet("terminal_payment_card.test", "exp_month"),
+					resource.TestCheckResourceAttrSet("terminal_payment_card.test", "exp_year"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terminal_payment_card.test", "last4"),
+				),
+			},
+			{
+				ResourceName:      "terminal_payment_card.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Stripe tokens can't be read back from the API
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+	})
+}
+
+func TestAccCard_disappears(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var card Card
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCardDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCardConfig(providerConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCardExists(client, "terminal_payment_card.test", &card),
+					func(s *terraform.State) error {
+						return client.DeleteCard(context.Background(), card.ID)
+					},
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCardExists(client *SDKClient, name string, card *Card) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+
+		found, err := client.GetCard(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*card = *found
+		return nil
+	}
+}
+
+func TestAccCard_readFailure(t *testing.T) {
+	failReads := true
+	providerConfig, client := testAccFlakySetup(t, func(w http.ResponseWriter, r *http.Request, _ http.Handler) bool {
+		if failReads && r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/card/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return true
+		}
+		return false
+	})
+
+	var firstID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCardDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				// The card is created but can't be read back
+				Config:      testAccCardConfig(providerConfig),
+				ExpectError: regexp.MustCompile(`Error reading payment card`),
+			},
+			{
+				// It was saved to state, so it is replaced rather than orphaned
+				PreConfig: func() {
+					cards, err := client.ListCards(context.Background())
+					if err != nil || len(cards) != 1 {
+						t.Fatalf("Expected the first card to exist, got %d cards: %v", len(cards), err)
+					}
+					firstID = cards[0].ID
+					failReads = false
+				},
+				Config: testAccCardConfig(providerConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terminal_payment_card.test", "last4"),
+					func(s *terraform.State) error {
+						if _, err := client.GetCard(context.Background(), firstID); !errors.Is(err, ErrNotFound) {
+							return fmt.Errorf("expected the first card %s to be deleted, got %v", firstID, err)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCardDestroy(client *SDKClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "terminal_payment_card" {
+				continue
+			}
+
+			_, err := client.GetCard(context.Background(), rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("payment card %s still exists", rs.Primary.ID)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCardConfig(providerConfig string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "terminal_payment_card" "test" {
+  token = %q
+}
+`, testAccStripeToken())
+}
@@ -0,0 +1,586 @@
+package terminal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &cartResource{}
+	_ resource.ResourceWithImportState    = &cartResource{}
+	_ resource.ResourceWithModifyPlan     = &cartResource{}
+	_ resource.ResourceWithValidateConfig = &cartResource{}
+)
+
+// cartID is the ID of the cart resource. Each account has a single cart.
+const cartID = "cart"
+
+// cartResource manages the contents of the account's server-side cart, and
+// optionally converts it into an order. Once converted, the cart is spent and
+// any change starts a new one.
+type cartResource struct {
+	client *SDKClient
+}
+
+type cartResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Item      types.List     `tfsdk:"item"`
+	AddressID types.String   `tfsdk:"address_id"`
+	CardID    types.String   `tfsdk:"card_id"`
+	Convert   types.Bool     `tfsdk:"convert"`
+	Subtotal  types.Int64    `tfsdk:"subtotal"`
+	Shipping  types.Int64    `tfsdk:"shipping"`
+	Total     types.Int64    `tfsdk:"total"`
+	OrderID   types.String   `tfsdk:"order_id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewCartResource() resource.Resource {
+	return &cartResource{}
+}
+
+func (r *cartResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cart"
+}
+
+func (r *cartResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The account's cart. Each account has a single cart, so manage at most one per account. Anything already in the cart is cleared when it is created",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Always \"cart\"",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"address_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the shipping address",
+			},
+			"card_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the payment card",
+			},
+			"convert": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Set to true to place an order for the cart. Requires address_id, card_id and at least one item. Once converted, any change starts a new cart",
+			},
+			"subtotal": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The price of the items in the cart in cents",
+			},
+			"shipping": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The shipping charge in cents, once an address is set",
+			},
+			"total": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The total charge of the cart in cents",
+			},
+			"order_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the order placed when the cart was converted",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"item": schema.ListNestedBlock{
+				Description: "A product variant in the cart. Repeat the block for each variant",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"variant_id": schema.StringAttribute{
+							Required:    true,
+							Description: "The ID of the product variant",
+						},
+						"quantity": schema.Int64Attribute{
+							Required:    true,
+							Description: "The number of units in the cart",
+							Validators:  []validator.Int64{int64validator.AtLeast(1)},
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *cartResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *cartResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config cartResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var lines []orderLineModel
+	resp.Diagnostics.Append(config.Item.ElementsAs(ctx, &lines, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, line := range lines {
+		if line.VariantID.IsUnknown() || line.VariantID.IsNull() {
+			continue
+		}
+
+		variantID := line.VariantID.ValueString()
+		if seen[variantID] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("item").AtListIndex(i).AtName("variant_id"),
+				"Duplicate cart item",
+				fmt.Sprintf("Variant %s appears in more than one item block, combine them into a single block with the total quantity.", variantID),
+			)
+		}
+		seen[variantID] = true
+	}
+
+	if !config.Convert.ValueBool() {
+		return
+	}
+
+	if config.AddressID.IsNull() || config.CardID.IsNull() || (len(lines) == 0 && !config.Item.IsUnknown()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("convert"),
+			"Incomplete cart",
+			"A cart can only be converted into an order once address_id, card_id and at least one item block are set.",
+		)
+	}
+}
+
+// ModifyPlan forces a new cart when a converted one changes, since its
+// contents have already been ordered
+func (r *cartResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan cartResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// No order is placed unless the cart is converted
+	if !plan.Convert.IsUnknown() && !plan.Convert.ValueBool() {
+		plan.OrderID = types.StringNull()
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state cartResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !state.OrderID.IsNull() {
+			planned, known, diags := plan.quantities(ctx)
+			resp.Diagnostics.Append(diags...)
+			current, _, diags := state.quantities(ctx)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Reordering the item blocks doesn't change what was ordered
+			if !known || !maps.Equal(planned, current) {
+				resp.RequiresReplace.Append(path.Root("item"))
+			}
+			if !plan.AddressID.Equal(state.AddressID) {
+				resp.RequiresReplace.Append(path.Root("address_id"))
+			}
+			if !plan.CardID.Equal(state.CardID) {
+				resp.RequiresReplace.Append(path.Root("card_id"))
+			}
+			if !plan.Convert.Equal(state.Convert) {
+				resp.RequiresReplace.Append(path.Root("convert"))
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *cartResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan cartResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Start from an empty cart so nothing added elsewhere, such as in the SSH
+	// shop, ends up in the order
+	if err := r.client.ClearCart(ctx); err != nil {
+		resp.Diagnostics.AddError("Error creating cart", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(cartID)
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan, &resp.State, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *cartResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state cartResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A converted cart has been emptied, and anything added to it since
+	// belongs to the next cart
+	if !state.OrderID.IsNull() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// A conversion that failed may still have placed the order
+	raw, diags := req.Private.GetKey(ctx, orderPendingKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if raw != nil {
+		var afterIndex int64
+		if err := json.Unmarshal(raw, &afterIndex); err != nil {
+			resp.Diagnostics.AddError("Error reading cart", err.Error())
+			return
+		}
+
+		quantities, _, diags := state.quantities(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		placed, err := findPlacedOrder(ctx, r.client, state.AddressID.ValueString(), quantities, afterIndex)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading cart", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, orderPendingKey, nil)...)
+
+		if placed != nil {
+			tflog.Info(ctx, "Adopting order placed by a failed cart conversion", map[string]interface{}{"order_id": placed.ID})
+			state.OrderID = types.StringValue(placed.ID)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+
+		// The cart still has to be converted
+		tflog.Warn(ctx, "Cart was not converted")
+		state.Convert = types.BoolValue(false)
+	}
+
+	cart, err := r.client.GetCart(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cart", err.Error())
+		return
+	}
+
+	current, _, diags := state.quantities(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured order of the item blocks unless the contents changed
+	if items := cartQuantities(cart); state.Item.IsNull() || !maps.Equal(items, current) {
+		state.Item, diags = flattenOrderLines(ctx, items)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	state.AddressID = optionalString(cart.AddressID)
+	state.CardID = optionalString(cart.CardID)
+	state.setCart(cart)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *cartResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state cartResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Any other change to a converted cart replaces it, so only the timeouts
+	// or the order of the item blocks can have changed
+	if !state.OrderID.IsNull() {
+		state.Item = plan.Item
+		state.Timeouts = plan.Timeouts
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	plan.ID = state.ID
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan, &resp.State, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// privateData is the provider's private data of a resource response
+type privateData interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// apply brings the cart in line with the model, converting it into an order
+// if requested, and fills in the computed attributes. Before converting, the
+// cart is saved to state as pending conversion.
+func (r *cartResource) apply(ctx context.Context, m *cartResourceModel, state *tfsdk.State, private privateData) diag.Diagnostics {
+	quantities, _, diags := m.quantities(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	cart, err := r.client.GetCart(ctx)
+	if err != nil {
+		diags.AddError("Error reading cart", err.Error())
+		return diags
+	}
+
+	// The API can't unset the address or card, so start over without them
+	if (m.AddressID.IsNull() && cart.AddressID != "") || (m.CardID.IsNull() && cart.CardID != "") {
+		if err := r.client.ClearCart(ctx); err != nil {
+			diags.AddError("Error updating cart", err.Error())
+			return diags
+		}
+		cart = &Cart{}
+	}
+
+	current := cartQuantities(cart)
+	for _, variantID := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := quantities[variantID]; ok {
+			continue
+		}
+		if _, err := r.client.SetCartItem(ctx, variantID, 0); err != nil {
+			diags.AddError("Error updating cart", err.Error())
+			return diags
+		}
+	}
+	for _, variantID := range slices.Sorted(maps.Keys(quantities)) {
+		if current[variantID] == quantities[variantID] {
+			continue
+		}
+		if _, err := r.client.SetCartItem(ctx, variantID, quantities[variantID]); err != nil {
+			diags.AddAttributeError(path.Root("item"), "Error updating cart", err.Error())
+			return diags
+		}
+	}
+
+	if addressID := m.AddressID.ValueString(); addressID != "" && addressID != cart.AddressID {
+		if err := r.client.SetCartAddress(ctx, addressID); err != nil {
+			diags.AddAttributeError(path.Root("address_id"), "Error updating cart", err.Error())
+			return diags
+		}
+	}
+	if cardID := m.CardID.ValueString(); cardID != "" && cardID != cart.CardID {
+		if err := r.client.SetCartCard(ctx, cardID); err != nil {
+			diags.AddAttributeError(path.Root("card_id"), "Error updating cart", err.Error())
+			return diags
+		}
+	}
+
+	cart, err = r.client.GetCart(ctx)
+	if err != nil {
+		diags.AddError("Error reading cart", err.Error())
+		return diags
+	}
+	m.setCart(cart)
+
+	if !m.Convert.ValueBool() {
+		m.OrderID = types.StringNull()
+		return diags
+	}
+
+	var items int64
+	for _, quantity := range quantities {
+		items += int64(quantity)
+	}
+
+	// Enforce the provider's spending guardrails before anything is charged
+	diags.Append(r.client.checkOrderLimits(ctx, items, cart.Subtotal+cart.Shipping, time.Now())...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Converting is never retried, and a request that fails may still have
+	// placed the order. Save the cart as pending conversion first, with where
+	// the order history ends, so a later read can look for the order rather
+	// than converting again.
+	existing, err := r.client.ListOrders(ctx)
+	if err != nil {
+		diags.AddError("Error converting cart", err.Error())
+		return diags
+	}
+	afterIndex := lastOrderIndex(existing)
+
+	raw, err := json.Marshal(afterIndex)
+	if err != nil {
+		diags.AddError("Error converting cart", err.Error())
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, orderPendingKey, raw)...)
+	m.OrderID = types.StringNull()
+	diags.Append(state.Set(ctx, m)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	order, err := r.client.ConvertCart(ctx)
+	if err != nil {
+		placed, findErr := findPlacedOrder(ctx, r.client, m.AddressID.ValueString(), quantities, afterIndex)
+		switch {
+		case findErr != nil:
+			diags.AddError(
+				"Error converting cart",
+				fmt.Sprintf("%s\n\nThe order may have been placed, but the account's orders couldn't be listed to check: %s\n\n"+
+					"The cart is saved in state as pending conversion, and the next refresh looks for the order. "+
+					"If the cart was being created it is also marked tainted: run terraform untaint so it isn't converted again.", err, findErr),
+			)
+			return diags
+		case placed == nil:
+			diags.AddError("Error converting cart", err.Error())
+			return diags
+		}
+
+		tflog.Warn(ctx, "Converting the cart failed but the order was placed, adopting it", map[string]interface{}{"order_id": placed.ID, "error": err.Error()})
+		order = placed
+	}
+	tflog.Info(ctx, "Converted cart into an order", map[string]interface{}{"order_id": order.ID})
+
+	m.OrderID = types.StringValue(order.ID)
+	diags.Append(private.SetKey(ctx, orderPendingKey, nil)...)
+
+	return diags
+}
+
+// setCart copies the computed charges of a cart into the model
+func (m *cartResourceModel) setCart(cart *Cart) {
+	m.Subtotal = types.Int64Value(cart.Subtotal)
+	m.Shipping = types.Int64Value(cart.Shipping)
+	m.Total = types.Int64Value(cart.Total)
+}
+
+// quantities returns the quantity of each variant in the item blocks. known is
+// false while any of them is unknown.
+func (m *cartResourceModel) quantities(ctx context.Context) (map[string]int, bool, diag.Diagnostics) {
+	quantities := make(map[string]int)
+	known, diags := addLineQuantities(ctx, m.Item, quantities)
+
+	return quantities, known, diags
+}
+
+// cartQuantities returns the quantity of each variant in a cart
+func cartQuantities(cart *Cart) map[string]int {
+	quantities := make(map[string]int, len(cart.Items))
+	for _, item := range cart.Items {
+		quantities[item.ProductVariantID] += item.Quantity
+	}
+
+	return quantities
+}
+
+func (r *cartResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state cartResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.OrderID.IsNull() {
+		tflog.Info(ctx, "Cart was converted into an order, removing from state only", map[string]interface{}{"order_id": state.OrderID.ValueString()})
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.client.ClearCart(ctx); err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Error clearing cart", err.Error())
+	}
+}
+
+// ImportState adopts the account's current cart. The only valid ID is "cart".
+func (r *cartResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != cartID {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Each account has a single cart, import it with the ID %q.", cartID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), cartID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("convert"), false)...)
+}
@@ -0,0 +1,291 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCart_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCartEmpty(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCartConfig(providerConfig, 1, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terminal_cart.test", "id", "cart"),
+					resource.TestCheckResourceAttrPair("terminal_cart.test", "address_id", "terminal_address.test", "id"),
+					resource.TestCheckResourceAttrPair("terminal_cart.test", "card_id", "terminal_payment_card.test", "id"),
+					testAccCheckCartSubtotal(client, "terminal_cart.test", 1),
+					resource.TestCheckResourceAttr("terminal_cart.test", "shipping", "0"),
+					resource.TestCheckResourceAttrPair("terminal_cart.test", "total", "terminal_cart.test", "subtotal"),
+					resource.TestCheckNoResourceAttr("terminal_cart.test", "order_id"),
+				),
+			},
+			{
+				Config: testAccCartConfig(providerConfig, 2, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terminal_cart.test", "item.0.quantity", "2"),
+					testAccCheckCartSubtotal(client, "terminal_cart.test", 2),
+				),
+			},
+			{
+				ResourceName:            "terminal_cart.test",
+				ImportState:             true,
+				ImportStateId:           "cart",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				Config: testAccCartConfig(providerConfig, 2, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terminal_cart.test", "order_id"),
+					testAccCheckCartOrder(client, "terminal_cart.test", 2),
+					testAccCheckCartEmpty(client),
+				),
+			},
+			{
+				// The converted cart is left alone by refresh
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("terminal_cart.test", "item.0.quantity", "2"),
+			},
+		},
+	})
+}
+
+func TestAccCart_incomplete(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "terminal_cart" "test" {
+  convert = true
+
+  item {
+    variant_id = %q
+    quantity   = 1
+  }
+}
+`, testAccVariantID()),
+				ExpectError: regexp.MustCompile(`Incomplete cart`),
+			},
+		},
+	})
+}
+
+func TestAccCart_reorderItems(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	config := func(first string, firstQuantity int, second string, secondQuantity int) string {
+		return testAccAddressConfig(providerConfig, false) + fmt.Sprintf(`
+resource "terminal_payment_card" "test" {
+  token = %q
+}
+
+resource "terminal_cart" "test" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+  convert    = true
+
+  item {
+    variant_id = %q
+    quantity   = %d
+  }
+
+  item {
+    variant_id = %q
+    quantity   = %d
+  }
+}
+`, testAccStripeToken(), first, firstQuantity, second, secondQuantity)
+	}
+	// A second variant from the mock catalog
+	other := "var_01JNH7GKX0Q8AG5KR5F4A3VHTB"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(testAccVariantID(), 1, other, 2),
+			},
+			{
+				// The same contents in another order don't convert a new cart
+				Config: config(other, 2, testAccVariantID(), 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terminal_cart.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(s *terraform.State) error {
+					orders, err := client.ListOrders(context.Background())
+					if err != nil {
+						return err
+					}
+					if len(orders) != 1 {
+						return fmt.Errorf("expected a single order, got %d", len(orders))
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccCart_failedConversion(t *testing.T) {
+	// The API converts the cart, but its response is lost. Once failOrders is
+	// set, the orders can't be listed afterwards either.
+	var failOrders, converted bool
+	providerConfig, client := testAccFlakySetup(t, func(w http.ResponseWriter, r *http.Request, api http.Handler) bool {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/cart/convert":
+			api.ServeHTTP(httptest.NewRecorder(), r)
+			converted = true
+			w.WriteHeader(http.StatusBadGateway)
+			return true
+		case failOrders && converted && r.URL.Path == "/order":
+			w.WriteHeader(http.StatusInternalServerError)
+			return true
+		}
+		return false
+	})
+
+	checkSingleOrder := func(s *terraform.State) error {
+		orders, err := client.ListOrders(context.Background())
+		if err != nil {
+			return err
+		}
+		if len(orders) != 1 {
+			return fmt.Errorf("expected a single order to be placed, got %d", len(orders))
+		}
+		return resource.TestCheckResourceAttr("terminal_cart.test", "order_id", orders[0].ID)(s)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The order that was placed is adopted rather than converting again
+				Config: testAccCartConfig(providerConfig, 1, true),
+				Check:  checkSingleOrder,
+			},
+			{
+				Config: testAccCartConfig(providerConfig, 2, false),
+			},
+			{
+				// Whether the order was placed can't be told yet
+				PreConfig: func() {
+					failOrders = true
+					converted = false
+				},
+				Config:      testAccCartConfig(providerConfig, 2, true),
+				ExpectError: regexp.MustCompile(`may have been placed`),
+			},
+			{
+				// The next refresh finds the order instead of converting again
+				PreConfig: func() {
+					failOrders = false
+				},
+				Config: testAccCartConfig(providerConfig, 2, true),
+				Check: func(s *terraform.State) error {
+					orders, err := client.ListOrders(context.Background())
+					if err != nil {
+						return err
+					}
+					if len(orders) != 2 {
+						return fmt.Errorf("expected two orders to be placed, got %d", len(orders))
+					}
+					return resource.TestCheckResourceAttr("terminal_cart.test", "order_id", orders[1].ID)(s)
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckCartSubtotal checks the cart's subtotal against the catalog
+// price of quantity units of the test variant
+func testAccCheckCartSubtotal(client *SDKClient, name string, quantity int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		products, err := client.ListProducts(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			for _, variant := range product.Variants {
+				if variant.ID == testAccVariantID() {
+					return resource.TestCheckResourceAttr(name, "subtotal", strconv.Itoa(variant.Price*quantity))(s)
+				}
+			}
+		}
+
+		return fmt.Errorf("variant %s not found in the catalog", testAccVariantID())
+	}
+}
+
+// testAccCheckCartOrder checks that the cart was converted into an order for
+// quantity units of the test variant
+func testAccCheckCartOrder(client *SDKClient, name string, quantity int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+
+		order, err := client.GetOrder(context.Background(), rs.Primary.Attributes["order_id"])
+		if err != nil {
+			return err
+		}
+		if order.Variants[testAccVariantID()] != quantity {
+			return fmt.Errorf("expected the order to contain %d of %s, got %v", quantity, testAccVariantID(), order.Variants)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCartEmpty(client *SDKClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cart, err := client.GetCart(context.Background())
+		if err != nil {
+			return err
+		}
+		if len(cart.Items) > 0 {
+			return fmt.Errorf("cart still holds %d items", len(cart.Items))
+		}
+
+		return nil
+	}
+}
+
+func testAccCartConfig(providerConfig string, quantity int, convert bool) string {
+	return testAccAddressConfig(providerConfig, false) + fmt.Sprintf(`
+resource "terminal_payment_card" "test" {
+  token = %q
+}
+
+resource "terminal_cart" "test" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+  convert    = %t
+
+  item {
+    variant_id = %q
+    quantity   = %d
+  }
+}
+`, testAccStripeToken(), convert, testAccVariantID(), quantity)
+}
@@ -2,182 +2,1296 @@ package terminal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
-func resourceOrder() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceOrderCreate,
-		ReadContext:   resourceOrderRead,
-		DeleteContext: resourceOrderDelete,
-		Schema: map[string]*schema.Schema{
-			"address_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The ID of the shipping address",
-			},
-			"card_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The ID of the payment card",
-			},
-			"variants": {
-				Type:        schema.TypeMap,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Map of product variant IDs to quantities",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+var (
+	_ resource.ResourceWithConfigure      = &orderResource{}
+	_ resource.ResourceWithImportState    = &orderResource{}
+	_ resource.ResourceWithModifyPlan     = &orderResource{}
+	_ resource.ResourceWithUpgradeState   = &orderResource{}
+	_ resource.ResourceWithValidateConfig = &orderResource{}
+)
+
+// orderResource places a coffee order. Orders can't be changed or cancelled
+// once placed, so every argument forces a new order.
+type orderResource struct {
+	client *SDKClient
+}
+
+type orderResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	AddressID types.String `tfsdk:"address_id"`
+	CardID    types.String `tfsdk:"card_id"`
+	Item      types.List   `tfsdk:"item"`
+	Variants  types.Map    `tfsdk:"variants"`
+
+	WaitForStatus types.String `tfsdk:"wait_for_status"`
+	OrderKey      types.String `tfsdk:"order_key"`
+
+	Status    types.String  `tfsdk:"status"`
+	Total     types.Float64 `tfsdk:"total"`
+	CreatedAt types.String  `tfsdk:"created_at"`
+
+	EstimatedSubtotalCents types.Int64   `tfsdk:"estimated_subtotal_cents"`
+	EstimatedShippingCents types.Int64   `tfsdk:"estimated_shipping_cents"`
+	EstimatedTotal         types.Float64 `tfsdk:"estimated_total"`
+
+	Items    types.List     `tfsdk:"items"`
+	Address  types.Object   `tfsdk:"address"`
+	Tracking types.Object   `tfsdk:"tracking"`
+	Amount   types.Object   `tfsdk:"amount"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// orderLineModel is an item block, one product variant to order
+type orderLineModel struct {
+	VariantID types.String `tfsdk:"variant_id"`
+	Quantity  types.Int64  `tfsdk:"quantity"`
+}
+
+var orderLineAttrTypes = map[string]attr.Type{
+	"variant_id": types.StringType,
+	"quantity":   types.Int64Type,
+}
+
+// orderItemModel is an element of an order's items attribute
+type orderItemModel struct {
+	ID               types.String `tfsdk:"id"`
+	Amount           types.Int64  `tfsdk:"amount"`
+	Quantity         types.Int64  `tfsdk:"quantity"`
+	Description      types.String `tfsdk:"description"`
+	ProductVariantID types.String `tfsdk:"product_variant_id"`
+}
+
+var orderItemAttrTypes = map[string]attr.Type{
+	"id":                 types.StringType,
+	"amount":             types.Int64Type,
+	"quantity":           types.Int64Type,
+	"description":        types.StringType,
+	"product_variant_id": types.StringType,
+}
+
+// orderAddressModel is the shipping address an order was sent to
+type orderAddressModel struct {
+	Name     types.String `tfsdk:"name"`
+	Street1  types.String `tfsdk:"street1"`
+	Street2  types.String `tfsdk:"street2"`
+	City     types.String `tfsdk:"city"`
+	Province types.String `tfsdk:"province"`
+	Zip      types.String `tfsdk:"zip"`
+	Country  types.String `tfsdk:"country"`
+	Phone    types.String `tfsdk:"phone"`
+}
+
+var orderAddressAttrTypes = map[string]attr.Type{
+	"name":     types.StringType,
+	"street1":  types.StringType,
+	"street2":  types.StringType,
+	"city":     types.StringType,
+	"province": types.StringType,
+	"zip":      types.StringType,
+	"country":  types.StringType,
+	"phone":    types.StringType,
+}
+
+// orderTrackingModel is the carrier tracking of an order
+type orderTrackingModel struct {
+	Number  types.String `tfsdk:"number"`
+	Service types.String `tfsdk:"service"`
+	URL     types.String `tfsdk:"url"`
+}
+
+var orderTrackingAttrTypes = map[string]attr.Type{
+	"number":  types.StringType,
+	"service": types.StringType,
+	"url":     types.StringType,
+}
+
+// orderAmountModel is the breakdown of an order's charge in cents
+type orderAmountModel struct {
+	Subtotal types.Int64 `tfsdk:"subtotal"`
+	Shipping types.Int64 `tfsdk:"shipping"`
+}
+
+var orderAmountAttrTypes = map[string]attr.Type{
+	"subtotal": types.Int64Type,
+	"shipping": types.Int64Type,
+}
+
+func NewOrderResource() resource.Resource {
+	return &orderResource{}
+}
+
+func (r *orderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_coffee_order"
+}
+
+func (r *orderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A coffee order. Orders can't be cancelled, so destroying one only removes it from state",
+		// Version 1 replaced the string-typed variants, items and address maps
+		// with typed attributes. Version 2 added item blocks.
+		Version: 2,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of the order",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"address_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the shipping address",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"card_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the payment card",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			// Replacement for changed quantities is decided in ModifyPlan, so
+			// moving between variants and item blocks doesn't force a new order
+			"variants": schema.MapAttribute{
+				Optional:           true,
+				ElementType:        types.Int64Type,
+				Description:        "Map of product variant IDs to quantities. Deprecated: use item blocks instead",
+				DeprecationMessage: "Use item blocks instead. variants will be removed in the next major version.",
+				Validators: []validator.Map{
+					mapvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
 				},
 			},
-			"status": {
-				Type:        schema.TypeString,
+			"order_key": schema.StringAttribute{
+				Optional:    true,
+				Description: "A unique key for this order, sent as the Idempotency-Key header when placing it. The API doesn't return the key, so it isn't used to find orders",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"wait_for_status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Wait after placing the order until it reaches this status, shipped or delivered, failing once the create timeout expires. Only applies when the order is placed. delivered is only seen if the API sends a carrier status, so waiting for it may time out",
+				Validators:  []validator.String{stringvalidator.OneOf(OrderStatusShipped, OrderStatusDelivered)},
+			},
+			"status": schema.StringAttribute{
 				Computed:    true,
-				Description: "The status of the order",
+				Description: "The status of the order: placed, or shipped once it has a tracking number or URL. delivered is best-effort, since it relies on a carrier status the API may not send, so a delivered order can stay shipped",
 			},
-			"total": {
-				Type:        schema.TypeFloat,
+			"total": schema.Float64Attribute{
 				Computed:    true,
 				Description: "The total amount of the order",
 			},
-			"created_at": {
-				Type:        schema.TypeString,
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp when the order was created, from the API's created field. The Terminal SDK doesn't model it, so it is null if the API doesn't send it",
+			},
+			"estimated_subtotal_cents": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The price of the ordered variants in cents, estimated from the product catalog at plan time",
+			},
+			"estimated_shipping_cents": schema.Int64Attribute{
 				Computed:    true,
-				Description: "Timestamp when the order was created",
+				Description: "The shipping charge in cents, estimated at plan time from the account's most recent order to the same country. Null if there is no such order, and unknown until the address exists",
 			},
-			"items": {
-				Type:        schema.TypeList,
+			"estimated_total": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The estimated subtotal plus estimated shipping, in dollars. Null when shipping can't be estimated",
+			},
+			"items": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "The items in the order",
-				Elem: &schema.Schema{
-					Type: schema.TypeMap,
-					Elem: &schema.Schema{
-						Type: schema.TypeString,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the line item",
+						},
+						"amount": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The price of the line item in cents",
+						},
+						"quantity": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of units ordered",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The description of the line item",
+						},
+						"product_variant_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the product variant ordered",
+						},
 					},
 				},
 			},
-			"address": {
-				Type:        schema.TypeMap,
+			"address": schema.SingleNestedAttribute{
 				Computed:    true,
 				Description: "The shipping address details",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				Attributes: map[string]schema.Attribute{
+					"name":     schema.StringAttribute{Computed: true, Description: "The name of the recipient"},
+					"street1":  schema.StringAttribute{Computed: true, Description: "The first line of the street address"},
+					"street2":  schema.StringAttribute{Computed: true, Description: "The second line of the street address"},
+					"city":     schema.StringAttribute{Computed: true, Description: "The city name"},
+					"province": schema.StringAttribute{Computed: true, Description: "The state or province"},
+					"zip":      schema.StringAttribute{Computed: true, Description: "The zip or postal code"},
+					"country":  schema.StringAttribute{Computed: true, Description: "The country code (e.g., US)"},
+					"phone":    schema.StringAttribute{Computed: true, Description: "The phone number of the recipient"},
+				},
+			},
+			"tracking": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The carrier tracking details. The number and URL are empty until the order ships",
+				Attributes: map[string]schema.Attribute{
+					"number":  schema.StringAttribute{Computed: true, Description: "The tracking number"},
+					"service": schema.StringAttribute{Computed: true, Description: "The shipping service (e.g., USPS)"},
+					"url":     schema.StringAttribute{Computed: true, Description: "The tracking URL"},
 				},
 			},
-			"card": {
-				Type:        schema.TypeMap,
+			"amount": schema.SingleNestedAttribute{
 				Computed:    true,
-				Description: "The payment card details (masked)",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				Description: "The breakdown of the order's charge",
+				Attributes: map[string]schema.Attribute{
+					"subtotal": schema.Int64Attribute{Computed: true, Description: "The price of the items in cents"},
+					"shipping": schema.Int64Attribute{Computed: true, Description: "The shipping charge in cents"},
 				},
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(10 * time.Minute),
-			Read:   schema.DefaultTimeout(5 * time.Minute),
-			Delete: schema.DefaultTimeout(5 * time.Minute),
+		Blocks: map[string]schema.Block{
+			"item": schema.ListNestedBlock{
+				Description: "A product variant to order. Repeat the block for each variant",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"variant_id": schema.StringAttribute{
+							Required:    true,
+							Description: "The ID of the product variant",
+						},
+						"quantity": schema.Int64Attribute{
+							Required:    true,
+							Description: "The number of units to order",
+							Validators:  []validator.Int64{int64validator.AtLeast(1)},
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+func (r *orderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *orderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config orderResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hasItems := len(config.Item.Elements()) > 0
+	hasVariants := !config.Variants.IsNull()
+
+	if hasItems == hasVariants && !config.Item.IsUnknown() && !config.Variants.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("item"),
+			"Invalid order contents",
+			"Exactly one of item blocks or variants must be set.",
+		)
+		return
+	}
+
+	var lines []orderLineModel
+	resp.Diagnostics.Append(config.Item.ElementsAs(ctx, &lines, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, line := range lines {
+		if line.VariantID.IsUnknown() || line.VariantID.IsNull() {
+			continue
+		}
+
+		variantID := line.VariantID.ValueString()
+		if seen[variantID] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("item").AtListIndex(i).AtName("variant_id"),
+				"Duplicate order item",
+				fmt.Sprintf("Variant %s appears in more than one item block, combine them into a single block with the total quantity.", variantID),
+			)
+		}
+		seen[variantID] = true
+	}
+}
+
+// ModifyPlan forces a new order when the ordered quantities change, however
+// they are written. Switching between variants and equivalent item blocks
+// only updates state. Orders that will be placed get a price estimate so the
+// cost shows up in the plan.
+func (r *orderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to estimate on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan orderResourceModel
 
-	addressID := d.Get("address_id").(string)
-	cardID := d.Get("card_id").(string)
-	
-	// Convert variants map
-	variantsRaw := d.Get("variants").(map[string]interface{})
-	variants := make(map[string]int)
-	for k, v := range variantsRaw {
-		quantity, err := strconv.Atoi(v.(string))
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state orderResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		planned, known, diags := plan.quantities(ctx)
+		resp.Diagnostics.Append(diags...)
+		current, _, diags := state.quantities(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		replaced := !plan.AddressID.Equal(state.AddressID) || !plan.CardID.Equal(state.CardID)
+		if known && maps.Equal(planned, current) && !replaced {
+			// No new order is placed, so the existing estimate stands
+			plan.EstimatedSubtotalCents = state.EstimatedSubtotalCents
+			plan.EstimatedShippingCents = state.EstimatedShippingCents
+			plan.EstimatedTotal = state.EstimatedTotal
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+			return
+		}
+
+		if !plan.Item.Equal(state.Item) {
+			resp.RequiresReplace.Append(path.Root("item"))
+		}
+		if !plan.Variants.Equal(state.Variants) {
+			resp.RequiresReplace.Append(path.Root("variants"))
+		}
+	}
+
+	// The client isn't available until the provider is configured
+	if r.client == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.estimate(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// estimate fills in the estimated price of the order. Items are priced from
+// the product catalog. The API only quotes shipping through the account's
+// cart, which can't be touched while planning, so it is estimated from what
+// the account's last order to the same country was charged.
+func (r *orderResource) estimate(ctx context.Context, m *orderResourceModel) diag.Diagnostics {
+	quantities, known, diags := m.quantities(ctx)
+	if diags.HasError() || !known {
+		return diags
+	}
+
+	products, err := r.client.ListProducts(ctx)
+	if err != nil {
+		diags.AddError("Error estimating order price", err.Error())
+		return diags
+	}
+
+	prices := make(map[string]int64)
+	for _, product := range products {
+		for _, variant := range product.Variants {
+			prices[variant.ID] = int64(variant.Price)
+		}
+	}
+
+	var subtotal int64
+	for _, variantID := range slices.Sorted(maps.Keys(quantities)) {
+		price, ok := prices[variantID]
+		if !ok {
+			diags.AddError(
+				"Unknown product variant",
+				fmt.Sprintf("Product variant %s is not in the Terminal catalog.", variantID),
+			)
+			continue
+		}
+		subtotal += price * int64(quantities[variantID])
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	m.EstimatedSubtotalCents = types.Int64Value(subtotal)
+
+	// The address may be created in the same run
+	if m.AddressID.IsUnknown() {
+		m.EstimatedShippingCents = types.Int64Unknown()
+		m.EstimatedTotal = types.Float64Unknown()
+		return diags
+	}
+
+	address, err := r.client.GetAddress(ctx, m.AddressID.ValueString())
+	if err != nil {
+		diags.AddError("Error estimating order price", err.Error())
+		return diags
+	}
+	orders, err := r.client.ListOrders(ctx)
+	if err != nil {
+		diags.AddError("Error estimating order price", err.Error())
+		return diags
+	}
+
+	shipping, ok := lastShipping(orders, address.Country)
+	if !ok {
+		m.EstimatedShippingCents = types.Int64Null()
+		m.EstimatedTotal = types.Float64Null()
+		return diags
+	}
+
+	m.EstimatedShippingCents = types.Int64Value(shipping)
+	m.EstimatedTotal = types.Float64Value(float64(subtotal+shipping) / 100.0)
+
+	return diags
+}
+
+// lastShipping returns the shipping charged on the latest order shipped to
+// country, and false if there is none
+func lastShipping(orders []*Order, country string) (int64, bool) {
+	var last *Order
+	for _, order := range orders {
+		if order.Shipping.Country == country && (last == nil || order.Index > last.Index) {
+			last = order
+		}
+	}
+	if last == nil {
+		return 0, false
+	}
+
+	return last.Amount.Shipping, true
+}
+
+// quantities returns the ordered quantity of each variant from either the item
+// blocks or the deprecated variants map. known is false while any of them is
+// unknown.
+func (m *orderResourceModel) quantities(ctx context.Context) (quantities map[string]int, known bool, diags diag.Diagnostics) {
+	if m.Item.IsUnknown() || m.Variants.IsUnknown() {
+		return nil, false, diags
+	}
+
+	quantities = make(map[string]int)
+	known = true
+
+	if !m.Variants.IsNull() {
+		for variantID, v := range m.Variants.Elements() {
+			quantity, ok := v.(types.Int64)
+			if !ok || quantity.IsUnknown() {
+				known = false
+				continue
+			}
+			quantities[variantID] += int(quantity.ValueInt64())
+		}
+	}
+
+	linesKnown, d := addLineQuantities(ctx, m.Item, quantities)
+	diags.Append(d...)
+
+	return quantities, known && linesKnown, diags
+}
+
+// addLineQuantities adds the quantities of item blocks to quantities. It
+// returns false if any of them is unknown.
+func addLineQuantities(ctx context.Context, items types.List, quantities map[string]int) (bool, diag.Diagnostics) {
+	var lines []orderLineModel
+	diags := items.ElementsAs(ctx, &lines, false)
+
+	known := true
+	for _, line := range lines {
+		if line.VariantID.IsUnknown() || line.Quantity.IsUnknown() {
+			known = false
+			continue
+		}
+		quantities[line.VariantID.ValueString()] += int(line.Quantity.ValueInt64())
+	}
+
+	return known, diags
+}
+
+func (r *orderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan orderResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	variants, _, diags := plan.quantities(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Estimates that depended on other resources, such as a variant ID from
+	// the catalog data source, can be completed now
+	if plan.EstimatedSubtotalCents.IsUnknown() || plan.EstimatedShippingCents.IsUnknown() || plan.EstimatedTotal.IsUnknown() {
+		resp.Diagnostics.Append(r.estimate(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var items int64
+	for _, quantity := range variants {
+		items += int64(quantity)
+	}
+	// Enforce the provider's spending guardrails before anything is charged.
+	// Until the order is placed only the estimate is known, and shipping
+	// counts as free when it can't be estimated.
+	estimatedCents := plan.EstimatedSubtotalCents.ValueInt64() + plan.EstimatedShippingCents.ValueInt64()
+	resp.Diagnostics.Append(r.client.checkOrderLimits(ctx, items, estimatedCents, time.Now())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Placing an order is never retried, and a request that fails may still
+	// have placed it. Note where the order history ends, so an order placed by
+	// this request can be told apart from earlier ones.
+	existing, err := r.client.ListOrders(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating order", err.Error())
+		return
+	}
+	afterIndex := lastOrderIndex(existing)
+
+	var orderID string
+	createdOrder, err := r.client.CreateOrder(ctx, &Order{
+		AddressID:      plan.AddressID.ValueString(),
+		CardID:         plan.CardID.ValueString(),
+		Variants:       variants,
+		IdempotencyKey: plan.OrderKey.ValueString(),
+	})
+	if err == nil {
+		orderID = createdOrder.ID
+	} else {
+		placed, findErr := findPlacedOrder(ctx, r.client, plan.AddressID.ValueString(), variants, afterIndex)
+		switch {
+		case findErr != nil:
+			// Without knowing whether the order was placed, save the plan and
+			// leave the next refresh to look for it
+			resp.Diagnostics.Append(r.savePendingOrder(ctx, &plan, afterIndex, resp)...)
+			resp.Diagnostics.AddError(
+				"Error creating order",
+				fmt.Sprintf("%s\n\nThe order may have been placed, but the account's orders couldn't be listed to check: %s\n\n"+
+					"The order is saved in state without an ID and marked tainted. Run terraform untaint, and the next refresh "+
+					"adopts the order if it was placed or removes it from state so it is placed again.", err, findErr),
+			)
+			return
+		case placed == nil:
+			resp.Diagnostics.AddError("Error creating order", err.Error())
+			return
+		}
+
+		tflog.Warn(ctx, "Placing the order failed but it was placed, adopting it", map[string]interface{}{"id": placed.ID, "error": err.Error()})
+		orderID = placed.ID
+	}
+
+	plan.ID = types.StringValue(orderID)
+
+	// Save the order straight away so a failed read doesn't orphan it
+	state := plan.withoutComputed()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	order, err := r.client.GetOrder(ctx, orderID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading order",
+			fmt.Sprintf("Order %s was placed but couldn't be read: %s\n\n"+
+				"The order is saved in state but marked tainted. Run terraform untaint to keep it rather than placing a new order on the next apply.",
+				orderID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setOrder(ctx, order)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.WaitForStatus.IsNull() {
+		order, err = waitForOrderStatus(ctx, r.client, order.ID, plan.WaitForStatus.ValueString())
 		if err != nil {
-			return diag.FromErr(err)
+			// The order has been placed either way, so keep everything known
+			// about it in state
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			resp.Diagnostics.AddError(
+				"Error waiting for order status",
+				fmt.Sprintf("Order %s was placed but did not reach status %q: %s\n\n"+
+					"The order is saved in state but marked tainted. Run terraform untaint to keep it rather than placing a new order on the next apply.",
+					plan.ID.ValueString(), plan.WaitForStatus.ValueString(), err),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(plan.setOrder(ctx, order)...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		variants[k] = quantity
 	}
 
-	order := &Order{
-		AddressID: addressID,
-		CardID:    cardID,
-		Variants:  variants,
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// orderPendingKey is the private data key holding the index of the last
+// order placed before an order whose request failed, while it isn't known
+// whether that order was placed
+const orderPendingKey = "placed_after_index"
+
+// lastOrderIndex returns the highest index among orders, or -1 if there are
+// none
+func lastOrderIndex(orders []*Order) int64 {
+	last := int64(-1)
+	for _, order := range orders {
+		last = max(last, order.Index)
 	}
 
-	createdOrder, err := client.CreateOrder(ctx, order)
+	return last
+}
+
+// findPlacedOrder looks for an order of variants to the given address placed
+// after the order at afterIndex, as happens when a request to place it fails
+// after the API accepted it
+func findPlacedOrder(ctx context.Context, client *SDKClient, addressID string, variants map[string]int, afterIndex int64) (*Order, error) {
+	address, err := client.GetAddress(ctx, addressID)
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, err
 	}
 
-	d.SetId(createdOrder.ID)
+	orders, err := client.ListOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	return resourceOrderRead(ctx, d, m)
+	return matchPlacedOrder(orders, afterIndex, address, variants), nil
 }
 
-func resourceOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*SDKClient)
+// matchPlacedOrder returns the first order after afterIndex with the given
+// contents shipped to address. Orders don't record the card they were charged
+// to, or the idempotency key, so only their index, items and shipping address
+// can be compared.
+func matchPlacedOrder(orders []*Order, afterIndex int64, address *Address, variants map[string]int) *Order {
+	var placed *Order
+	for _, order := range orders {
+		if order.Index <= afterIndex || !maps.Equal(order.Variants, variants) ||
+			matchShippingAddress([]*Address{address}, order.Shipping) == nil {
+			continue
+		}
+		if placed == nil || order.Index < placed.Index {
+			placed = order
+		}
+	}
 
-	var diags diag.Diagnostics
+	return placed
+}
 
-	orderID := d.Id()
+// savePendingOrder saves an order that may or may not have been placed, with
+// a null ID and the index it would follow in private data for Read to find it
+func (r *orderResource) savePendingOrder(ctx context.Context, m *orderResourceModel, afterIndex int64, resp *resource.CreateResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	order, err := client.GetOrder(ctx, orderID)
+	raw, err := json.Marshal(afterIndex)
 	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Set computed fields
-	d.Set("status", order.Status)
-	d.Set("total", order.Total)
-	d.Set("created_at", order.CreatedAt)
-	
-	// Convert items to a format compatible with TypeList of TypeMap
-	if order.Items != nil {
-		itemsForSchema := make([]map[string]string, len(order.Items))
-		for i, item := range order.Items {
-			itemMap := make(map[string]string)
-			for k, v := range item {
-				itemMap[k] = fmt.Sprintf("%v", v)
+		diags.AddError("Error saving order", err.Error())
+		return diags
+	}
+	diags.Append(resp.Private.SetKey(ctx, orderPendingKey, raw)...)
+
+	state := m.withoutComputed()
+	state.ID = types.StringNull()
+	diags.Append(resp.State.Set(ctx, &state)...)
+
+	return diags
+}
+
+// withoutComputed returns a copy of the model with the attributes read from
+// the API set to null, so it can be saved before the order is read
+func (m orderResourceModel) withoutComputed() orderResourceModel {
+	m.Status = types.StringNull()
+	m.Total = types.Float64Null()
+	m.CreatedAt = types.StringNull()
+	m.Items = types.ListNull(types.ObjectType{AttrTypes: orderItemAttrTypes})
+	m.Address = types.ObjectNull(orderAddressAttrTypes)
+	m.Tracking = types.ObjectNull(orderTrackingAttrTypes)
+	m.Amount = types.ObjectNull(orderAmountAttrTypes)
+
+	return m
+}
+
+// orderStatusPollInterval is how often an order is polled while waiting for
+// wait_for_status
+var orderStatusPollInterval = 30 * time.Second
+
+// orderStatuses are the lifecycle statuses in the order they are reached
+var orderStatuses = []string{OrderStatusPlaced, OrderStatusShipped, OrderStatusDelivered}
+
+// waitForOrderStatus polls an order until it reaches the given status, or a
+// later one, giving up when the context expires
+func waitForOrderStatus(ctx context.Context, client *SDKClient, orderID, status string) (*Order, error) {
+	i := slices.Index(orderStatuses, status)
+
+	conf := &retry.StateChangeConf{
+		Pending: orderStatuses[:i],
+		Target:  orderStatuses[i:],
+		Refresh: func() (any, string, error) {
+			order, err := client.GetOrder(ctx, orderID)
+			if err != nil {
+				return nil, "", err
 			}
-			itemsForSchema[i] = itemMap
+
+			tflog.Debug(ctx, "Polled order status", map[string]interface{}{"id": orderID, "status": order.Status})
+			return order, order.Status, nil
+		},
+		PollInterval: orderStatusPollInterval,
+		Timeout:      10 * time.Minute,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conf.Timeout = time.Until(deadline)
+	}
+
+	result, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*Order), nil
+}
+
+func (r *orderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state orderResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// An order saved without an ID failed to be placed, and may or may not
+	// have been
+	if state.ID.IsNull() {
+		raw, diags := req.Private.GetKey(ctx, orderPendingKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if raw == nil {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		var afterIndex int64
+		if err := json.Unmarshal(raw, &afterIndex); err != nil {
+			resp.Diagnostics.AddError("Error reading order", err.Error())
+			return
+		}
+
+		variants, _, diags := state.quantities(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		d.Set("items", itemsForSchema)
+
+		placed, err := findPlacedOrder(ctx, r.client, state.AddressID.ValueString(), variants, afterIndex)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading order", err.Error())
+			return
+		}
+		if placed == nil {
+			tflog.Warn(ctx, "Order was not placed, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		tflog.Info(ctx, "Adopting order placed by a failed request", map[string]interface{}{"id": placed.ID})
+		state.ID = types.StringValue(placed.ID)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, orderPendingKey, nil)...)
+	}
+
+	order, err := r.client.GetOrder(ctx, state.ID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "Order not found, removing from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading order", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(state.setOrder(ctx, order)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Convert address and card to a format compatible with TypeMap
-	if order.Address != nil {
-		addressMap := make(map[string]string)
-		for k, v := range order.Address {
-			addressMap[k] = fmt.Sprintf("%v", v)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// setOrder copies the computed attributes of an order into the model
+func (m *orderResourceModel) setOrder(ctx context.Context, order *Order) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	m.Status = types.StringValue(order.Status)
+	m.Total = types.Float64Value(order.Total)
+	m.CreatedAt = optionalString(order.CreatedAt)
+
+	m.Items, d = flattenOrderItems(ctx, order.Items)
+	diags.Append(d...)
+	m.Address, d = flattenOrderAddress(ctx, order.Shipping)
+	diags.Append(d...)
+	m.Tracking, d = flattenOrderTracking(ctx, order.Tracking)
+	diags.Append(d...)
+	m.Amount, d = flattenOrderAmount(ctx, order.Amount)
+	diags.Append(d...)
+
+	return diags
+}
+
+// flattenOrderItems converts order items into the items attribute value
+func flattenOrderItems(ctx context.Context, items []OrderItem) (types.List, diag.Diagnostics) {
+	models := make([]orderItemModel, len(items))
+	for i, item := range items {
+		models[i] = orderItemModel{
+			ID:               types.StringValue(item.ID),
+			Amount:           types.Int64Value(item.Amount),
+			Quantity:         types.Int64Value(item.Quantity),
+			Description:      types.StringValue(item.Description),
+			ProductVariantID: types.StringValue(item.ProductVariantID),
 		}
-		d.Set("address", addressMap)
 	}
-	
-	if order.Card != nil {
-		cardMap := make(map[string]string)
-		for k, v := range order.Card {
-			cardMap[k] = fmt.Sprintf("%v", v)
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: orderItemAttrTypes}, models)
+}
+
+// flattenOrderAddress converts an order's shipping details into the address
+// attribute value
+func flattenOrderAddress(ctx context.Context, shipping OrderShipping) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, orderAddressAttrTypes, orderAddressModel{
+		Name:     types.StringValue(shipping.Name),
+		Street1:  types.StringValue(shipping.Street1),
+		Street2:  optionalString(shipping.Street2),
+		City:     types.StringValue(shipping.City),
+		Province: optionalString(shipping.Province),
+		Zip:      types.StringValue(shipping.Zip),
+		Country:  types.StringValue(shipping.Country),
+		Phone:    optionalString(shipping.Phone),
+	})
+}
+
+// flattenOrderTracking converts an order's tracking details into the tracking
+// attribute value
+func flattenOrderTracking(ctx context.Context, tracking OrderTracking) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, orderTrackingAttrTypes, orderTrackingModel{
+		Number:  optionalString(tracking.Number),
+		Service: optionalString(tracking.Service),
+		URL:     optionalString(tracking.URL),
+	})
+}
+
+// flattenOrderAmount converts an order's charges into the amount attribute
+// value
+func flattenOrderAmount(ctx context.Context, amount OrderAmount) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, orderAmountAttrTypes, orderAmountModel{
+		Subtotal: types.Int64Value(amount.Subtotal),
+		Shipping: types.Int64Value(amount.Shipping),
+	})
+}
+
+// flattenOrderLines converts variant quantities into item blocks, sorted by
+// variant ID so the result is stable
+func flattenOrderLines(ctx context.Context, variants map[string]int) (types.List, diag.Diagnostics) {
+	lines := make([]orderLineModel, 0, len(variants))
+	for _, variantID := range slices.Sorted(maps.Keys(variants)) {
+		lines = append(lines, orderLineModel{
+			VariantID: types.StringValue(variantID),
+			Quantity:  types.Int64Value(int64(variants[variantID])),
+		})
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: orderLineAttrTypes}, lines)
+}
+
+// flattenOrderVariants converts variant quantities into the variants
+// attribute value
+func flattenOrderVariants(ctx context.Context, variants map[string]int) (types.Map, diag.Diagnostics) {
+	values := make(map[string]int64, len(variants))
+	for k, v := range variants {
+		values[k] = int64(v)
+	}
+
+	return types.MapValueFrom(ctx, types.Int64Type, values)
+}
+
+func (r *orderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state orderResourceModel
+
+	// Only the timeouts, wait_for_status, order_key, or a rewrite of the same
+	// quantities between variants and item blocks, can change in place. Neither is sent to the API, and the
+	// computed attributes are unknown in the plan so they are kept from state.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Item = plan.Item
+	state.Variants = plan.Variants
+	state.WaitForStatus = plan.WaitForStatus
+	state.OrderKey = plan.OrderKey
+	state.Timeouts = plan.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *orderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Terminal Shop API doesn't support cancelling orders, so this is a no-op
+	// and the order is only forgotten from Terraform's perspective
+}
+
+// ImportState accepts "<order_id>", optionally followed by ":<address_id>"
+// and/or ":<card_id>". Orders don't record which saved address and card they
+// were placed with, so when omitted the address is matched against the
+// order's shipping details and the card is only inferred if the account has
+// exactly one.
+func (r *orderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orderID, addressID, cardID, err := parseOrderImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	order, err := r.client.GetOrder(ctx, orderID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading order", err.Error())
+		return
+	}
+
+	inferredAddressID, inferredCardID, err := inferOrderSources(ctx, r.client, order)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading order", err.Error())
+		return
+	}
+
+	if addressID == "" {
+		if inferredAddressID == "" {
+			resp.Diagnostics.AddError(
+				"Unable to infer the order's address",
+				fmt.Sprintf("no saved address matches the shipping address of order %s, import it as \"%s:<address_id>:<card_id>\"", orderID, orderID),
+			)
+			return
 		}
-		d.Set("card", cardMap)
+		addressID = inferredAddressID
 	}
 
-	return diags
+	if cardID == "" {
+		if inferredCardID == "" {
+			resp.Diagnostics.AddError(
+				"Unable to infer the order's payment card",
+				fmt.Sprintf("the payment card of order %s can't be inferred unless the account has exactly one saved card, import it as \"%s:<card_id>\"", orderID, orderID),
+			)
+			return
+		}
+		cardID = inferredCardID
+	}
+
+	lines, diags := flattenOrderLines(ctx, order.Variants)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), orderID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("address_id"), addressID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("card_id"), cardID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("item"), lines)...)
+}
+
+// parseOrderImportID splits an order import ID into its order, address and
+// card IDs, telling the optional parts apart by their prefix
+func parseOrderImportID(id string) (orderID, addressID, cardID string, err error) {
+	parts := strings.Split(id, ":")
+	orderID = parts[0]
+	if orderID == "" || len(parts) > 3 {
+		return "", "", "", fmt.Errorf("unexpected import ID %q, expected <order_id>[:<address_id>][:<card_id>]", id)
+	}
+
+	for _, part := range parts[1:] {
+		switch {
+		case strings.HasPrefix(part, "shp_") && addressID == "":
+			addressID = part
+		case strings.HasPrefix(part, "crd_") && cardID == "":
+			cardID = part
+		default:
+			return "", "", "", fmt.Errorf("unexpected import ID %q, expected <order_id>[:<address_id>][:<card_id>]", id)
+		}
+	}
+
+	return orderID, addressID, cardID, nil
+}
+
+// inferOrderSources returns the saved address and payment card an order was
+// placed with, or "" where they can't be told. Orders don't record them, so
+// the address is matched against the order's shipping details and the card is
+// only inferred if the account has exactly one.
+func inferOrderSources(ctx context.Context, client *SDKClient, order *Order) (addressID, cardID string, err error) {
+	addresses, err := client.ListAddresses(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	cards, err := client.ListCards(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	addressID, cardID = orderSources(addresses, cards, order)
+	return addressID, cardID, nil
+}
+
+// orderSources is inferOrderSources for addresses and cards that have already
+// been listed
+func orderSources(addresses []*Address, cards []*Card, order *Order) (addressID, cardID string) {
+	if address := matchShippingAddress(addresses, order.Shipping); address != nil {
+		addressID = address.ID
+	}
+	if len(cards) == 1 {
+		cardID = cards[0].ID
+	}
+
+	return addressID, cardID
+}
+
+// matchShippingAddress finds the saved address that an order was shipped to
+func matchShippingAddress(addresses []*Address, shipping OrderShipping) *Address {
+	for _, a := range addresses {
+		if a.Name == shipping.Name &&
+			a.Street1 == shipping.Street1 &&
+			a.Street2 == shipping.Street2 &&
+			a.City == shipping.City &&
+			a.State == shipping.Province &&
+			a.Zip == shipping.Zip &&
+			a.Country == shipping.Country {
+			return a
+		}
+	}
+
+	return nil
 }
 
-func resourceOrderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// client := m.(*SDKClient) - unused since this is a no-op
+// orderResourceModelV0 is the state of an order written by the SDKv2 provider,
+// where variants, items and address were maps of strings
+type orderResourceModelV0 struct {
+	ID        types.String        `tfsdk:"id"`
+	AddressID types.String        `tfsdk:"address_id"`
+	CardID    types.String        `tfsdk:"card_id"`
+	Variants  map[string]string   `tfsdk:"variants"`
+	Status    types.String        `tfsdk:"status"`
+	Total     types.Float64       `tfsdk:"total"`
+	CreatedAt types.String        `tfsdk:"created_at"`
+	Items     []map[string]string `tfsdk:"items"`
+	Address   map[string]string   `tfsdk:"address"`
+	Card      map[string]string   `tfsdk:"card"`
+	Timeouts  timeouts.Value      `tfsdk:"timeouts"`
+}
+
+func (r *orderResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+
+	stringMap := func() schema.MapAttribute {
+		return schema.MapAttribute{Computed: true, ElementType: types.StringType}
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":         schema.StringAttribute{Computed: true},
+					"address_id": schema.StringAttribute{Required: true},
+					"card_id":    schema.StringAttribute{Required: true},
+					"variants":   schema.MapAttribute{Required: true, ElementType: types.StringType},
+					"status":     schema.StringAttribute{Computed: true},
+					"total":      schema.Float64Attribute{Computed: true},
+					"created_at": schema.StringAttribute{Computed: true},
+					"items": schema.ListAttribute{
+						Computed:    true,
+						ElementType: types.MapType{ElemType: types.StringType},
+					},
+					"address": stringMap(),
+					"card":    stringMap(),
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Delete: true,
+					}),
+				},
+			},
+			StateUpgrader: upgradeOrderStateV0,
+		},
+		// Version 2 only added item blocks, which decode as null from version
+		// 1 state, so the current schema can read it
+		1: {
+			PriorSchema:   &current.Schema,
+			StateUpgrader: upgradeOrderStateV1,
+		},
+	}
+}
+
+// upgradeOrderStateV0 converts the string maps of a version 0 order into typed
+// attributes
+func upgradeOrderStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior orderResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parseInt := func(attribute, s string) int64 {
+		if s == "" {
+			return 0
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to upgrade order state", fmt.Sprintf("%s: %q is not an integer", attribute, s))
+		}
+		return n
+	}
+
+	variants := make(map[string]int, len(prior.Variants))
+	for k, v := range prior.Variants {
+		variants[k] = int(parseInt("variants."+k, v))
+	}
+
+	items := make([]OrderItem, len(prior.Items))
+	for i, item := range prior.Items {
+		items[i] = OrderItem{
+			ID:               item["id"],
+			Amount:           parseInt("items.amount", item["amount"]),
+			Quantity:         parseInt("items.quantity", item["quantity"]),
+			Description:      item["description"],
+			ProductVariantID: item["productVariantID"],
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Version 0 kept the tracking details in the card map
+	shipping := OrderShipping{
+		Name:     prior.Address["name"],
+		Street1:  prior.Address["street1"],
+		Street2:  prior.Address["street2"],
+		City:     prior.Address["city"],
+		Province: prior.Address["province"],
+		Zip:      prior.Address["zip"],
+		Country:  prior.Address["country"],
+		Phone:    prior.Address["phone"],
+	}
+	tracking := OrderTracking{
+		Number:  prior.Card["tracking_number"],
+		Service: prior.Card["tracking_service"],
+		URL:     prior.Card["tracking_url"],
+	}
+
+	state := orderResourceModel{
+		ID:        prior.ID,
+		AddressID: prior.AddressID,
+		CardID:    prior.CardID,
+		Item:      types.ListValueMust(types.ObjectType{AttrTypes: orderLineAttrTypes}, nil),
+		Status:    prior.Status,
+		Total:     prior.Total,
+		CreatedAt: prior.CreatedAt,
+		Amount:    types.ObjectNull(orderAmountAttrTypes),
+		Timeouts:  prior.Timeouts,
+	}
+
 	var diags diag.Diagnostics
+	state.Variants, diags = flattenOrderVariants(ctx, variants)
+	resp.Diagnostics.Append(diags...)
+	state.Items, diags = flattenOrderItems(ctx, items)
+	resp.Diagnostics.Append(diags...)
+	state.Address, diags = flattenOrderAddress(ctx, shipping)
+	resp.Diagnostics.Append(diags...)
+	state.Tracking, diags = flattenOrderTracking(ctx, tracking)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Terminal Shop API doesn't support cancelling orders, so this is a no-op
-	// We just forget about the resource from Terraform's perspective
-	d.SetId("")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-	return diags
-}
\ No newline at end of file
+// upgradeOrderStateV1 gives a version 1 order, which always used variants, an
+// empty list of item blocks
+func upgradeOrderStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state orderResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Item = types.ListValueMust(types.ObjectType{AttrTypes: orderLineAttrTypes}, nil)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,796 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal/mockapi"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccOrder_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckAddressDestroy(client),
+			testAccCheckCardDestroy(client),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrderConfig(providerConfig),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						// The price is known from the catalog, even though the
+						// address is created in the same run. Shipping depends
+						// on the address.
+						plancheck.ExpectKnownValue("terminal_coffee_order.test", tfjsonpath.New("estimated_subtotal_cents"), knownvalue.NotNull()),
+						plancheck.ExpectUnknownValue("terminal_coffee_order.test", tfjsonpath.New("estimated_shipping_cents")),
+						plancheck.ExpectUnknownValue("terminal_coffee_order.test", tfjsonpath.New("estimated_total")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOrderExists(client, "terminal_coffee_order.test"),
+					resource.TestCheckResourceAttrSet("terminal_coffee_order.test", "estimated_subtotal_cents"),
+					// No earlier order shows what shipping costs
+					resource.TestCheckNoResourceAttr("terminal_coffee_order.test", "estimated_shipping_cents"),
+					resource.TestCheckNoResourceAttr("terminal_coffee_order.test", "estimated_total"),
+					resource.TestCheckResourceAttrPair("terminal_coffee_order.test", "address_id", "terminal_address.test", "id"),
+					resource.TestCheckResourceAttrPair("terminal_coffee_order.test", "card_id", "terminal_payment_card.test", "id"),
+					resource.TestCheckResourceAttr("terminal_coffee_order.test", "item.#", "1"),
+					resource.TestCheckResourceAttr("terminal_coffee_order.test", "item.0.variant_id", testAccVariantID()),
+					resource.TestCheckResourceAttr("terminal_coffee_order.test", "item.0.quantity", "1"),
+					resource.TestCheckResourceAttrSet("terminal_coffee_order.test", "status"),
+					resource.TestCheckResourceAttrSet("terminal_coffee_order.test", "total"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terminal_coffee_order.test", "item.0.quantity", "1"),
+				),
+			},
+			{
+				ResourceName:      "terminal_coffee_order.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Estimates are only made when planning a new order
+				ImportStateVerifyIgnore: []string{"estimated_subtotal_cents", "estimated_shipping_cents", "estimated_total"},
+				// The dev account may hold several cards, so name the one used
+				ImportStateIdFunc: testAccOrderImportID("terminal_coffee_order.test", "terminal_payment_card.test"),
+			},
+		},
+	})
+}
+
+func TestAccOrder_deprecatedVariants(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrderVariantsConfig(providerConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOrderExists(client, "terminal_coffee_order.test"),
+					resource.TestCheckResourceAttr("terminal_coffee_order.test", "variants."+testAccVariantID(), "1"),
+				),
+			},
+			{
+				// Moving to item blocks with the same quantities keeps the order
+				Config: testAccOrderConfig(providerConfig),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terminal_coffee_order.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terminal_coffee_order.test", "variants.%"),
+					resource.TestCheckResourceAttr("terminal_coffee_order.test", "item.0.quantity", "1"),
+					resource.TestCheckResourceAttrSet("terminal_coffee_order.test", "status"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrder_invalidItems(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "terminal_coffee_order" "test" {
+  address_id = "shp_1"
+  card_id    = "crd_1"
+
+  item {
+    variant_id = "var_1"
+    quantity   = 1
+  }
+
+  item {
+    variant_id = "var_1"
+    quantity   = 2
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Duplicate order item`),
+			},
+			{
+				Config: providerConfig + `
+resource "terminal_coffee_order" "test" {
+  address_id = "shp_1"
+  card_id    = "crd_1"
+
+  item {
+    variant_id = "var_1"
+    quantity   = 0
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be at least 1`),
+			},
+			{
+				Config: providerConfig + `
+resource "terminal_coffee_order" "test" {
+  address_id = "shp_1"
+  card_id    = "crd_1"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Exactly one of item blocks or variants`),
+			},
+		},
+	})
+}
+
+func TestAccOrder_estimate(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	// Orders to Canada are charged for shipping
+	savedConfig := testAccAddressLocationConfig(providerConfig, "ON", "M5V 2T6", "CA") + fmt.Sprintf(`
+resource "terminal_payment_card" "test" {
+  token = %q
+}
+
+resource "terminal_coffee_order" "first" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+
+  item {
+    variant_id = %q
+    quantity   = 2
+  }
+}
+`, testAccStripeToken(), testAccVariantID())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: savedConfig,
+			},
+			{
+				// The subtotal is the catalog price of the items, and shipping
+				// is what the first order to the same country was charged
+				Config: savedConfig + fmt.Sprintf(`
+resource "terminal_coffee_order" "test" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+
+  item {
+    variant_id = %q
+    quantity   = 1
+  }
+}
+`, testAccVariantID()),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("terminal_coffee_order.test", tfjsonpath.New("estimated_subtotal_cents"), knownvalue.Int64Exact(2200)),
+						plancheck.ExpectKnownValue("terminal_coffee_order.test", tfjsonpath.New("estimated_shipping_cents"), knownvalue.Int64Exact(1500)),
+						plancheck.ExpectKnownValue("terminal_coffee_order.test", tfjsonpath.New("estimated_total"), knownvalue.Float64Exact(37)),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("terminal_coffee_order.test", "estimated_subtotal_cents", "terminal_coffee_order.test", "amount.subtotal"),
+					resource.TestCheckResourceAttrPair("terminal_coffee_order.test", "estimated_shipping_cents", "terminal_coffee_order.test", "amount.shipping"),
+					resource.TestCheckResourceAttrPair("terminal_coffee_order.test", "estimated_total", "terminal_coffee_order.test", "total"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrder_waitForStatusTimeout(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Nothing ships the order, so the wait runs out
+				Config: strings.Replace(testAccOrderConfig(providerConfig), `
+  item {`, `
+  wait_for_status = "shipped"
+
+  timeouts {
+    create = "3s"
+  }
+
+  item {`, 1),
+				ExpectError: regexp.MustCompile(`did not reach status\s+"shipped"`),
+			},
+		},
+	})
+}
+
+func TestAccOrder_failedRequest(t *testing.T) {
+	// The API places the order, but its response is lost
+	providerConfig, client := testAccFlakySetup(t, func(w http.ResponseWriter, r *http.Request, api http.Handler) bool {
+		if r.Method == http.MethodPost && r.URL.Path == "/order" {
+			api.ServeHTTP(httptest.NewRecorder(), r)
+			w.WriteHeader(http.StatusBadGateway)
+			return true
+		}
+		return false
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(testAccOrderConfig(providerConfig), `
+  item {`, `
+  order_key = "offsite-coffee"
+
+  item {`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					// The order that was placed is adopted rather than ordered again
+					func(s *terraform.State) error {
+						orders, err := client.ListOrders(context.Background())
+						if err != nil {
+							return err
+						}
+						if len(orders) != 1 {
+							return fmt.Errorf("expected a single order to be placed, got %d", len(orders))
+						}
+
+						id := s.RootModule().Resources["terminal_coffee_order.test"].Primary.ID
+						if id != orders[0].ID {
+							return fmt.Errorf("expected the placed order %s to be adopted, got %s", orders[0].ID, id)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr("terminal_coffee_order.test", "order_key", "offsite-coffee"),
+				),
+			},
+		},
+	})
+}
+
+func TestOrderResource_pendingOrder(t *testing.T) {
+	for name, placed := range map[string]bool{"placed": true, "not placed": false} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			api := mockapi.New()
+			direct := httptest.NewServer(api)
+			t.Cleanup(direct.Close)
+
+			client, err := NewClient(direct.URL, mockapi.DefaultToken)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			// Placing the order fails, whether or not the API placed it, and
+			// the orders can't be listed afterwards to tell
+			failed := false
+			flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/order" && (r.Method == http.MethodPost || failed) {
+					if r.Method == http.MethodPost && placed {
+						api.ServeHTTP(httptest.NewRecorder(), r)
+					}
+					failed = true
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				api.ServeHTTP(w, r)
+			}))
+			t.Cleanup(flaky.Close)
+
+			applyResp, objectType := testApplyOrder(t, client, flaky.URL)
+			if len(applyResp.Diagnostics) != 1 || !strings.Contains(applyResp.Diagnostics[0].Detail, "may have been placed") {
+				t.Fatalf("Expected only an error that the order may have been placed, got %+v", applyResp.Diagnostics)
+			}
+
+			// The order is saved without an ID, and the next refresh finds out
+			// whether it was placed
+			state, err := applyResp.NewState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if !state.IsFullyKnown() {
+				t.Errorf("Expected the saved state to be fully known, got %s", state)
+			}
+
+			healthy, _ := testConfigureProvider(t, direct.URL)
+			readResp, err := healthy.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     "terminal_coffee_order",
+				CurrentState: applyResp.NewState,
+				Private:      applyResp.Private,
+			})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			testCheckDiagnostics(t, readResp.Diagnostics)
+
+			state, err = readResp.NewState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			orders, err := client.ListOrders(ctx)
+			if err != nil {
+				t.Fatalf("Error listing orders: %v", err)
+			}
+
+			if !placed {
+				if len(orders) != 0 || !state.IsNull() {
+					t.Errorf("Expected an order that wasn't placed to be removed from state, got %d orders and state %s", len(orders), state)
+				}
+				return
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := state.As(&attributes); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if len(orders) != 1 || !attributes["id"].Equal(tftypes.NewValue(tftypes.String, orders[0].ID)) {
+				t.Errorf("Expected the placed order to be adopted, got %d orders and id %s", len(orders), attributes["id"])
+			}
+		})
+	}
+}
+
+func TestOrderResource_createReadFailure(t *testing.T) {
+	// The order is placed, but reading it back fails
+	api := mockapi.New()
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/order/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(flaky.Close)
+
+	client, err := NewClient(flaky.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resp, objectType := testApplyOrder(t, client, flaky.URL)
+	if len(resp.Diagnostics) != 1 || !strings.Contains(resp.Diagnostics[0].Detail, "terraform untaint") {
+		t.Fatalf("Expected only an error telling to untaint the order, got %+v", resp.Diagnostics)
+	}
+
+	// The whole plan is saved, so an untainted order doesn't plan a new one
+	state, err := resp.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !state.IsFullyKnown() {
+		t.Errorf("Expected the saved state to be fully known, got %s", state)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, name := range []string{"id", "address_id", "card_id", "item", "estimated_subtotal_cents"} {
+		if attributes[name].IsNull() {
+			t.Errorf("Expected %s to be saved, got %s", name, state)
+		}
+	}
+}
+
+// testApplyOrder creates an order for a new address and card with the
+// provider configured for endpoint, returning the response and the order's
+// type. client is used to create the address and card.
+func testApplyOrder(t *testing.T, client *SDKClient, endpoint string) (*tfprotov6.ApplyResourceChangeResponse, tftypes.Object) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	address, err := client.CreateAddress(ctx, &Address{Name: "Office", Street1: "2 Main St", City: "Springfield", Zip: "12345", Country: "US"})
+	if err != nil {
+		t.Fatalf("Error creating address: %v", err)
+	}
+	card, err := client.CreateCard(ctx, &Card{Token: testAccStripeToken()})
+	if err != nil {
+		t.Fatalf("Error creating card: %v", err)
+	}
+
+	server, schemas := testConfigureProvider(t, endpoint)
+	objectType := schemas.ResourceSchemas["terminal_coffee_order"].ValueType().(tftypes.Object)
+
+	itemType := objectType.AttributeTypes["item"]
+	item := tftypes.NewValue(itemType, []tftypes.Value{
+		tftypes.NewValue(itemType.(tftypes.List).ElementType, map[string]tftypes.Value{
+			"variant_id": tftypes.NewValue(tftypes.String, testAccVariantID()),
+			"quantity":   tftypes.NewValue(tftypes.Number, big.NewFloat(1)),
+		}),
+	})
+	config := map[string]tftypes.Value{
+		"address_id": tftypes.NewValue(tftypes.String, address.ID),
+		"card_id":    tftypes.NewValue(tftypes.String, card.ID),
+		"item":       item,
+	}
+	planned := maps.Clone(config)
+	for _, name := range []string{"id", "status", "total", "created_at", "estimated_subtotal_cents", "estimated_total", "items", "address", "tracking", "amount"} {
+		planned[name] = tftypes.NewValue(objectType.AttributeTypes[name], tftypes.UnknownValue)
+	}
+
+	priorState, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "terminal_coffee_order",
+		PriorState:   &priorState,
+		PlannedState: testNullObject(t, objectType, planned),
+		Config:       testNullObject(t, objectType, config),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return resp, objectType
+}
+
+func testAccCheckOrderExists(client *SDKClient, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+
+		_, err := client.GetOrder(context.Background(), rs.Primary.ID)
+		return err
+	}
+}
+
+func testAccOrderImportID(orderName, cardName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		order, ok := s.RootModule().Resources[orderName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", orderName)
+		}
+		card, ok := s.RootModule().Resources[cardName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", cardName)
+		}
+		return order.Primary.ID + ":" + card.Primary.ID, nil
+	}
+}
+
+func testAccOrderConfig(providerConfig string) string {
+	return testAccAddressConfig(providerConfig, false) + fmt.Sprintf(`
+resource "terminal_payment_card" "test" {
+  token = %q
+}
+
+resource "terminal_coffee_order" "test" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+
+  item {
+    variant_id = %q
+    quantity   = 1
+  }
+}
+`, testAccStripeToken(), testAccVariantID())
+}
+
+func testAccOrderVariantsConfig(providerConfig string) string {
+	return testAccAddressConfig(providerConfig, false) + fmt.Sprintf(`
+resource "terminal_payment_card" "test" {
+  token = %q
+}
+
+resource "terminal_coffee_order" "test" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+
+  variants = {
+    %q = "1"
+  }
+}
+`, testAccStripeToken(), testAccVariantID())
+}
+
+func TestParseOrderImportID(t *testing.T) {
+	testCases := []struct {
+		id                         string
+		orderID, addressID, cardID string
+		expectErr                  bool
+	}{
+		{id: "ord_1", orderID: "ord_1"},
+		{id: "ord_1:crd_2", orderID: "ord_1", cardID: "crd_2"},
+		{id: "ord_1:shp_3:crd_2", orderID: "ord_1", addressID: "shp_3", cardID: "crd_2"},
+		{id: "ord_1:crd_2:shp_3", orderID: "ord_1", addressID: "shp_3", cardID: "crd_2"},
+		{id: "ord_1:crd_2:crd_3", expectErr: true},
+		{id: "ord_1:var_2", expectErr: true},
+		{id: ":crd_2", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			orderID, addressID, cardID, err := parseOrderImportID(tc.id)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if orderID != tc.orderID || addressID != tc.addressID || cardID != tc.cardID {
+				t.Errorf("Got (%q, %q, %q), expected (%q, %q, %q)", orderID, addressID, cardID, tc.orderID, tc.addressID, tc.cardID)
+			}
+		})
+	}
+}
+
+func TestMatchShippingAddress(t *testing.T) {
+	addresses := []*Address{
+		{ID: "shp_1", Name: "Home", Street1: "1 Main St", City: "Springfield", Zip: "12345", Country: "US"},
+		{ID: "shp_2", Name: "Office", Street1: "2 Main St", City: "Springfield", State: "IL", Zip: "12345", Country: "US"},
+	}
+
+	shipping := OrderShipping{
+		Name:     "Office",
+		Street1:  "2 Main St",
+		City:     "Springfield",
+		Province: "IL",
+		Zip:      "12345",
+		Country:  "US",
+	}
+
+	address := matchShippingAddress(addresses, shipping)
+	if address == nil || address.ID != "shp_2" {
+		t.Fatalf("Expected shp_2, got %+v", address)
+	}
+
+	shipping.Zip = "99999"
+	if address := matchShippingAddress(addresses, shipping); address != nil {
+		t.Errorf("Expected no match, got %s", address.ID)
+	}
+}
+
+func TestOrderStateUpgradeV0(t *testing.T) {
+	attributes := testUpgradeState(t, "terminal_coffee_order", 0, `{
+  "id": "ord_1",
+  "address_id": "shp_1",
+  "card_id": "crd_1",
+  "variants": {"var_1": "2"},
+  "status": "USPS",
+  "total": 44,
+  "created_at": "",
+  "items": [{"id": "itm_1", "amount": "4400", "quantity": "2", "productVariantID": "var_1"}],
+  "address": {"name": "Test User", "street1": "123 Test St", "city": "Test City", "province": "CA", "zip": "12345", "country": "US"},
+  "card": {"tracking_number": "9400", "tracking_service": "USPS", "tracking_url": "https://example.com/9400"},
+  "timeouts": null
+}`)
+
+	var variants map[string]tftypes.Value
+	if err := attributes["variants"].As(&variants); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var quantity big.Float
+	if err := variants["var_1"].As(&quantity); err != nil {
+		t.Fatalf("variants should hold numbers: %s", err)
+	}
+	if q, _ := quantity.Int64(); q != 2 {
+		t.Errorf("expected quantity 2, got %d", q)
+	}
+
+	var items []tftypes.Value
+	if err := attributes["items"].As(&items); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var item map[string]tftypes.Value
+	if err := items[0].As(&item); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var amount big.Float
+	if err := item["amount"].As(&amount); err != nil {
+		t.Fatalf("items.amount should be a number: %s", err)
+	}
+	if a, _ := amount.Int64(); a != 4400 {
+		t.Errorf("expected amount 4400, got %d", a)
+	}
+	var variantID string
+	if err := item["product_variant_id"].As(&variantID); err != nil || variantID != "var_1" {
+		t.Errorf("expected product_variant_id var_1, got %q (%v)", variantID, err)
+	}
+
+	var address map[string]tftypes.Value
+	if err := attributes["address"].As(&address); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var province string
+	if err := address["province"].As(&province); err != nil || province != "CA" {
+		t.Errorf("expected province CA, got %q (%v)", province, err)
+	}
+
+	// Tracking details moved out of the card map
+	var tracking map[string]tftypes.Value
+	if err := attributes["tracking"].As(&tracking); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var number string
+	if err := tracking["number"].As(&number); err != nil || number != "9400" {
+		t.Errorf("expected tracking number 9400, got %q (%v)", number, err)
+	}
+}
+
+func TestOrderStateUpgradeV1(t *testing.T) {
+	attributes := testUpgradeState(t, "terminal_coffee_order", 1, `{
+  "id": "ord_1",
+  "address_id": "shp_1",
+  "card_id": "crd_1",
+  "variants": {"var_1": 2},
+  "status": "placed",
+  "total": 44,
+  "created_at": "",
+  "items": [],
+  "address": null,
+  "card": {},
+  "timeouts": null
+}`)
+
+	var lines []tftypes.Value
+	if err := attributes["item"].As(&lines); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(lines) != 0 {
+		t.Errorf("expected no item blocks, got %d", len(lines))
+	}
+
+	var variants map[string]tftypes.Value
+	if err := attributes["variants"].As(&variants); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, ok := variants["var_1"]; !ok {
+		t.Errorf("expected variants to be kept, got %v", variants)
+	}
+}
+
+func TestWaitForOrderStatus(t *testing.T) {
+	orderStatusPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { orderStatusPollInterval = 30 * time.Second })
+
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	address, err := client.CreateAddress(ctx, &Address{Name: "Test User", Street1: "123 Test St", City: "Test City", Zip: "12345", Country: "US"})
+	if err != nil {
+		t.Fatalf("Error creating address: %v", err)
+	}
+	card, err := client.CreateCard(ctx, &Card{Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("Error creating card: %v", err)
+	}
+	order, err := client.CreateOrder(ctx, &Order{AddressID: address.ID, CardID: card.ID, Variants: map[string]int{"var_9U04ZMMHXK": 1}})
+	if err != nil {
+		t.Fatalf("Error creating order: %v", err)
+	}
+
+	// The order ships while it is being polled
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		if err := server.API.ShipOrder(order.ID, "9400", "USPS", "https://example.com/9400"); err != nil {
+			t.Errorf("Error shipping order: %v", err)
+		}
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	shipped, err := waitForOrderStatus(waitCtx, client, order.ID, OrderStatusShipped)
+	if err != nil {
+		t.Fatalf("Error waiting for order: %v", err)
+	}
+	if shipped.Status != OrderStatusShipped || shipped.Tracking.Number != "9400" {
+		t.Errorf("Expected a shipped order with tracking, got %+v", shipped)
+	}
+
+	// Nothing delivers it, so the wait runs out
+	waitCtx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := waitForOrderStatus(waitCtx, client, order.ID, OrderStatusDelivered); err == nil {
+		t.Error("Expected the wait for delivery to time out")
+	}
+}
+
+func TestMatchPlacedOrder(t *testing.T) {
+	address := &Address{Name: "Office", Street1: "2 Main St", City: "Springfield", Zip: "12345", Country: "US"}
+	shipping := OrderShipping{Name: "Office", Street1: "2 Main St", City: "Springfield", Zip: "12345", Country: "US"}
+	elsewhere := OrderShipping{Name: "Home", Street1: "9 Elm St", City: "Springfield", Zip: "12345", Country: "US"}
+	variants := map[string]int{"var_1": 2}
+
+	orders := []*Order{
+		{ID: "ord_1", Index: 0, Variants: variants, Shipping: shipping},
+		{ID: "ord_2", Index: 1, Variants: map[string]int{"var_1": 1}, Shipping: shipping},
+		{ID: "ord_3", Index: 2, Variants: variants, Shipping: elsewhere},
+		{ID: "ord_5", Index: 4, Variants: variants, Shipping: shipping},
+		{ID: "ord_4", Index: 3, Variants: variants, Shipping: shipping},
+	}
+
+	// Earlier orders with the same contents are never adopted, since they may
+	// be deliberate re-orders or belong to other resources
+	if order := matchPlacedOrder(orders, 0, address, variants); order == nil || order.ID != "ord_4" {
+		t.Errorf("Expected the first later order with the same contents, ord_4, got %+v", order)
+	}
+	if order := matchPlacedOrder(orders, 4, address, variants); order != nil {
+		t.Errorf("Expected no match without a later order, got %s", order.ID)
+	}
+	if order := matchPlacedOrder(orders, -1, address, map[string]int{"var_2": 1}); order != nil {
+		t.Errorf("Expected no match for other contents, got %s", order.ID)
+	}
+
+	if last := lastOrderIndex(orders); last != 4 {
+		t.Errorf("Expected the last order index to be 4, got %d", last)
+	}
+	if last := lastOrderIndex(nil); last != -1 {
+		t.Errorf("Expected -1 without orders, got %d", last)
+	}
+}
+
+func TestLastShipping(t *testing.T) {
+	orders := []*Order{
+		{ID: "ord_2", Index: 1, Amount: OrderAmount{Shipping: 1800}, Shipping: OrderShipping{Country: "CA"}},
+		{ID: "ord_1", Index: 0, Amount: OrderAmount{Shipping: 1500}, Shipping: OrderShipping{Country: "CA"}},
+		{ID: "ord_3", Index: 2, Amount: OrderAmount{Shipping: 0}, Shipping: OrderShipping{Country: "US"}},
+	}
+
+	if shipping, ok := lastShipping(orders, "CA"); !ok || shipping != 1800 {
+		t.Errorf("Expected the latest order to Canada's 1800, got %d (%t)", shipping, ok)
+	}
+	if shipping, ok := lastShipping(orders, "US"); !ok || shipping != 0 {
+		t.Errorf("Expected free shipping to the US, got %d (%t)", shipping, ok)
+	}
+	if _, ok := lastShipping(orders, "GB"); ok {
+		t.Error("Expected no estimate without an order to the country")
+	}
+}
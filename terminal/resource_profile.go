@@ -0,0 +1,185 @@
+package terminal
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &profileResource{}
+	_ resource.ResourceWithImportState = &profileResource{}
+)
+
+// emailPattern is a loose check for an email address, leaving the rest to
+// the API
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// profileResource manages the name and email of the account the API token
+// belongs to. The profile always exists, so creating the resource adopts it
+// and destroying it only removes it from state.
+type profileResource struct {
+	client *SDKClient
+}
+
+type profileResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Email    types.String   `tfsdk:"email"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewProfileResource() resource.Resource {
+	return &profileResource{}
+}
+
+func (r *profileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_profile"
+}
+
+func (r *profileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The profile of the account the API token belongs to. The profile can't be deleted, so destroying it only removes it from state",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of the user",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the user",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"email": schema.StringAttribute{
+				Required:    true,
+				Description: "The email address of the user",
+				Validators:  []validator.String{stringvalidator.RegexMatches(emailPattern, "must be an email address")},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *profileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *profileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan profileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	profile, err := r.client.UpdateProfile(ctx, &Profile{
+		Name:  plan.Name.ValueString(),
+		Email: plan.Email.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating profile", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(profile.ID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *profileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state profileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	profile, err := r.client.GetProfile(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading profile", err.Error())
+		return
+	}
+
+	if state.ID.ValueString() != profile.ID {
+		// The API token now belongs to another account
+		tflog.Warn(ctx, "Profile belongs to a different user, removing from state", map[string]interface{}{"id": state.ID.ValueString(), "user_id": profile.ID})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Name = types.StringValue(profile.Name)
+	state.Email = types.StringValue(profile.Email)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan profileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if _, err := r.client.UpdateProfile(ctx, &Profile{
+		Name:  plan.Name.ValueString(),
+		Email: plan.Email.ValueString(),
+	}); err != nil {
+		resp.Diagnostics.AddError("Error updating profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *profileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Profiles can't be deleted, so the profile is only forgotten from
+	// Terraform's perspective and keeps its current name and email
+}
+
+// ImportState adopts the profile by user ID
+func (r *profileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,69 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProfile_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProfileConfig(providerConfig, "Coffee Ops", "not-an-email"),
+				ExpectError: regexp.MustCompile(`must be an email address`),
+			},
+			{
+				Config: testAccProfileConfig(providerConfig, "Coffee Ops", "ops@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terminal_profile.test", "id"),
+					testAccCheckProfile(client, "Coffee Ops", "ops@example.com"),
+				),
+			},
+			{
+				Config: testAccProfileConfig(providerConfig, "Coffee Platform", "platform@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terminal_profile.test", "name", "Coffee Platform"),
+					testAccCheckProfile(client, "Coffee Platform", "platform@example.com"),
+				),
+			},
+			{
+				ResourceName:            "terminal_profile.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+// testAccCheckProfile checks the account's profile through the API
+func testAccCheckProfile(client *SDKClient, name, email string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		profile, err := client.GetProfile(context.Background())
+		if err != nil {
+			return err
+		}
+		if profile.Name != name || profile.Email != email {
+			return fmt.Errorf("expected profile %q <%s>, got %q <%s>", name, email, profile.Name, profile.Email)
+		}
+
+		return nil
+	}
+}
+
+func testAccProfileConfig(providerConfig, name, email string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "terminal_profile" "test" {
+  name  = %q
+  email = %q
+}
+`, name, email)
+}
@@ -0,0 +1,448 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &subscriptionResource{}
+	_ resource.ResourceWithImportState    = &subscriptionResource{}
+	_ resource.ResourceWithUpgradeState   = &subscriptionResource{}
+	_ resource.ResourceWithValidateConfig = &subscriptionResource{}
+)
+
+// subscriptionResource manages a recurring coffee delivery
+type subscriptionResource struct {
+	client *SDKClient
+}
+
+type subscriptionResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	ProductVariantID types.String   `tfsdk:"product_variant_id"`
+	Quantity         types.Int64    `tfsdk:"quantity"`
+	AddressID        types.String   `tfsdk:"address_id"`
+	CardID           types.String   `tfsdk:"card_id"`
+	Schedule         types.Object   `tfsdk:"schedule"`
+	Next             types.String   `tfsdk:"next"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+type subscriptionScheduleModel struct {
+	Type     types.String `tfsdk:"type"`
+	Interval types.Int64  `tfsdk:"interval"`
+}
+
+var subscriptionScheduleAttrTypes = map[string]attr.Type{
+	"type":     types.StringType,
+	"interval": types.Int64Type,
+}
+
+func NewSubscriptionResource() resource.Resource {
+	return &subscriptionResource{}
+}
+
+func (r *subscriptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscription"
+}
+
+func (r *subscriptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A recurring coffee delivery",
+		// Version 1 turned the schedule block into a nested attribute
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			// The API can't edit subscriptions, so the ID changes on every update
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the subscription",
+			},
+			"product_variant_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the product variant to subscribe to",
+			},
+			"quantity": schema.Int64Attribute{
+				Required:    true,
+				Description: "The number of units delivered with each shipment",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"address_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the shipping address",
+			},
+			"card_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the payment card",
+			},
+			"schedule": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "How often the subscription ships",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Required:    true,
+						Description: "The schedule type: \"fixed\" for Terminal's standard schedule or \"weekly\" for a custom interval",
+						Validators:  []validator.String{stringvalidator.OneOf("fixed", "weekly")},
+					},
+					"interval": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The number of weeks between shipments. Required for \"weekly\" schedules, and not allowed for \"fixed\" ones",
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+				},
+			},
+			"next": schema.StringAttribute{
+				Computed:    true,
+				Description: "The next shipment and billing date",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *subscriptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *subscriptionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var schedule types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schedule"), &schedule)...)
+	if resp.Diagnostics.HasError() || schedule.IsNull() || schedule.IsUnknown() {
+		return
+	}
+
+	var s subscriptionScheduleModel
+	resp.Diagnostics.Append(schedule.As(ctx, &s, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if s.Type.ValueString() == "weekly" && s.Interval.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schedule").AtName("interval"),
+			"Missing schedule interval",
+			"schedule.interval is required when schedule.type is \"weekly\"",
+		)
+	}
+
+	// Fixed schedules follow Terminal's standard interval, and the API drops
+	// any other
+	if !s.Type.IsUnknown() && s.Type.ValueString() != "weekly" && !s.Interval.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schedule").AtName("interval"),
+			"Unexpected schedule interval",
+			"schedule.interval can only be set when schedule.type is \"weekly\"",
+		)
+	}
+}
+
+// expandSubscription converts the model into the client's Subscription
+func (m *subscriptionResourceModel) expandSubscription(ctx context.Context) (*Subscription, diag.Diagnostics) {
+	var schedule subscriptionScheduleModel
+
+	diags := m.Schedule.As(ctx, &schedule, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &Subscription{
+		ProductVariantID: m.ProductVariantID.ValueString(),
+		Quantity:         int(m.Quantity.ValueInt64()),
+		AddressID:        m.AddressID.ValueString(),
+		CardID:           m.CardID.ValueString(),
+		Schedule: SubscriptionSchedule{
+			Type:     schedule.Type.ValueString(),
+			Interval: int(schedule.Interval.ValueInt64()),
+		},
+	}, diags
+}
+
+// setSubscription copies the API's view of a subscription into the model
+func (m *subscriptionResourceModel) setSubscription(ctx context.Context, subscription *Subscription) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(subscription.ID)
+	m.ProductVariantID = types.StringValue(subscription.ProductVariantID)
+	m.Quantity = types.Int64Value(int64(subscription.Quantity))
+	m.AddressID = types.StringValue(subscription.AddressID)
+	m.CardID = types.StringValue(subscription.CardID)
+	m.Schedule, diags = flattenSubscriptionSchedule(ctx, subscription.Schedule.Type, int64(subscription.Schedule.Interval))
+	m.Next = types.StringValue(subscription.Next)
+
+	return diags
+}
+
+// flattenSubscriptionSchedule builds the schedule attribute value. Fixed
+// schedules have no interval, which is stored as null to match configuration.
+func flattenSubscriptionSchedule(ctx context.Context, scheduleType string, interval int64) (types.Object, diag.Diagnostics) {
+	schedule := subscriptionScheduleModel{
+		Type:     types.StringValue(scheduleType),
+		Interval: types.Int64Null(),
+	}
+	if interval != 0 {
+		schedule.Interval = types.Int64Value(interval)
+	}
+
+	return types.ObjectValueFrom(ctx, subscriptionScheduleAttrTypes, schedule)
+}
+
+func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	subscription, diags := plan.expandSubscription(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createdSubscription, err := r.client.CreateSubscription(ctx, subscription)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating subscription", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setSubscription(ctx, createdSubscription)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *subscriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	subscription, err := r.client.GetSubscription(ctx, state.ID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "Subscription not found, removing from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading subscription", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(state.setSubscription(ctx, subscription)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Only the timeouts changed, which the API never sees, so the
+	// subscription is kept as it is
+	if plan.ProductVariantID.Equal(state.ProductVariantID) &&
+		plan.Quantity.Equal(state.Quantity) &&
+		plan.AddressID.Equal(state.AddressID) &&
+		plan.CardID.Equal(state.CardID) &&
+		plan.Schedule.Equal(state.Schedule) {
+		state.Timeouts = plan.Timeouts
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	subscription, diags := plan.expandSubscription(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Terminal Shop API doesn't support editing subscriptions, so the client
+	// swaps in a new one and we track its ID from here on
+	updatedSubscription, err := r.client.UpdateSubscription(ctx, state.ID.ValueString(), subscription)
+	if updatedSubscription == nil {
+		resp.Diagnostics.AddError("Error updating subscription", err.Error())
+		return
+	}
+
+	// The replacement is live even if the old subscription couldn't be
+	// cancelled, so it is saved to state either way
+	resp.Diagnostics.Append(plan.setSubscription(ctx, updatedSubscription)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error cancelling replaced subscription",
+			fmt.Sprintf("Subscription %s replaced %s and is saved in state, but %s could not be cancelled and may still be billing: %s\n\n"+
+				"Cancel it in the Terminal account.",
+				updatedSubscription.ID, state.ID.ValueString(), state.ID.ValueString(), err),
+		)
+	}
+}
+
+func (r *subscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subscriptionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// A subscription that is already gone counts as cancelled
+	if err := r.client.DeleteSubscription(ctx, state.ID.ValueString()); err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Error deleting subscription", err.Error())
+	}
+}
+
+func (r *subscriptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// subscriptionResourceModelV0 is the state of a subscription written by the
+// SDKv2 provider, where schedule was a single-element block list
+type subscriptionResourceModelV0 struct {
+	ID               types.String                `tfsdk:"id"`
+	ProductVariantID types.String                `tfsdk:"product_variant_id"`
+	Quantity         types.Int64                 `tfsdk:"quantity"`
+	AddressID        types.String                `tfsdk:"address_id"`
+	CardID           types.String                `tfsdk:"card_id"`
+	Schedule         []subscriptionScheduleModel `tfsdk:"schedule"`
+	Next             types.String                `tfsdk:"next"`
+	Timeouts         timeouts.Value              `tfsdk:"timeouts"`
+}
+
+func (r *subscriptionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                 schema.StringAttribute{Computed: true},
+					"product_variant_id": schema.StringAttribute{Required: true},
+					"quantity":           schema.Int64Attribute{Required: true},
+					"address_id":         schema.StringAttribute{Required: true},
+					"card_id":            schema.StringAttribute{Required: true},
+					"next":               schema.StringAttribute{Computed: true},
+				},
+				Blocks: map[string]schema.Block{
+					"schedule": schema.ListNestedBlock{
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"type":     schema.StringAttribute{Required: true},
+								"interval": schema.Int64Attribute{Optional: true},
+							},
+						},
+					},
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+				},
+			},
+			StateUpgrader: upgradeSubscriptionStateV0,
+		},
+	}
+}
+
+// upgradeSubscriptionStateV0 unwraps the schedule block list of a version 0
+// subscription into the schedule object
+func upgradeSubscriptionStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior subscriptionResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := subscriptionResourceModel{
+		ID:               prior.ID,
+		ProductVariantID: prior.ProductVariantID,
+		Quantity:         prior.Quantity,
+		AddressID:        prior.AddressID,
+		CardID:           prior.CardID,
+		Schedule:         types.ObjectNull(subscriptionScheduleAttrTypes),
+		Next:             prior.Next,
+		Timeouts:         prior.Timeouts,
+	}
+
+	if len(prior.Schedule) > 0 {
+		var diags diag.Diagnostics
+		state.Schedule, diags = flattenSubscriptionSchedule(ctx, prior.Schedule[0].Type.ValueString(), prior.Schedule[0].Interval.ValueInt64())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,143 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSubscription_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	var subscriptionID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSubscriptionDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSubscriptionConfig(providerConfig, `{ type = "fixed" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terminal_subscription.test", "schedule.type", "fixed"),
+					resource.TestCheckNoResourceAttr("terminal_subscription.test", "schedule.interval"),
+					resource.TestCheckResourceAttrSet("terminal_subscription.test", "next"),
+				),
+			},
+			{
+				// Subscriptions can't be edited, so this swaps in a new one
+				Config: testAccSubscriptionConfig(providerConfig, `{ type = "weekly", interval = 2 }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terminal_subscription.test", "schedule.type", "weekly"),
+					resource.TestCheckResourceAttr("terminal_subscription.test", "schedule.interval", "2"),
+					resource.TestCheckResourceAttrWith("terminal_subscription.test", "id", func(id string) error {
+						subscriptionID = id
+						return nil
+					}),
+				),
+			},
+			{
+				// The timeouts never reach the API, so the subscription is kept
+				Config: testAccSubscriptionConfig(providerConfig, `{ type = "weekly", interval = 2 }
+
+  timeouts {
+    update = "10m"
+  }`),
+				Check: resource.TestCheckResourceAttrPtr("terminal_subscription.test", "id", &subscriptionID),
+			},
+			{
+				ResourceName:            "terminal_subscription.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccSubscription_invalidSchedule(t *testing.T) {
+	providerConfig, _ := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSubscriptionConfig(providerConfig, `{ type = "weekly" }`),
+				ExpectError: regexp.MustCompile(`Missing schedule interval`),
+			},
+			{
+				Config:      testAccSubscriptionConfig(providerConfig, `{ type = "fixed", interval = 2 }`),
+				ExpectError: regexp.MustCompile(`Unexpected schedule interval`),
+			},
+		},
+	})
+}
+
+func testAccCheckSubscriptionDestroy(client *SDKClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "terminal_subscription" {
+				continue
+			}
+
+			_, err := client.GetSubscription(context.Background(), rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("subscription %s still exists", rs.Primary.ID)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccSubscriptionConfig(providerConfig, schedule string) string {
+	return testAccAddressConfig(providerConfig, false) + testAccCardConfig("") + fmt.Sprintf(`
+resource "terminal_subscription" "test" {
+  product_variant_id = %q
+  quantity           = 1
+  address_id         = terminal_address.test.id
+  card_id            = terminal_payment_card.test.id
+  schedule           = %s
+}
+`, testAccVariantID(), schedule)
+}
+
+func TestSubscriptionStateUpgradeV0(t *testing.T) {
+	attributes := testUpgradeState(t, "terminal_subscription", 0, `{
+  "id": "sub_1",
+  "product_variant_id": "var_1",
+  "quantity": 2,
+  "address_id": "shp_1",
+  "card_id": "crd_1",
+  "schedule": [{"type": "weekly", "interval": 3}],
+  "next": "2025-01-01T00:00:00Z",
+  "timeouts": null
+}`)
+
+	var schedule map[string]tftypes.Value
+	if err := attributes["schedule"].As(&schedule); err != nil {
+		t.Fatalf("schedule should be an object: %s", err)
+	}
+
+	var scheduleType string
+	if err := schedule["type"].As(&scheduleType); err != nil || scheduleType != "weekly" {
+		t.Errorf("expected schedule type weekly, got %q (%v)", scheduleType, err)
+	}
+
+	var interval big.Float
+	if err := schedule["interval"].As(&interval); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if i, _ := interval.Int64(); i != 3 {
+		t.Errorf("expected interval 3, got %d", i)
+	}
+}
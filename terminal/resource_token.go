@@ -0,0 +1,254 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &tokenResource{}
+	_ resource.ResourceWithImportState = &tokenResource{}
+	_ resource.ResourceWithModifyPlan  = &tokenResource{}
+)
+
+// tokenResource mints a personal access token. The API only reveals the token
+// value when it is created, so it is kept from state afterwards.
+type tokenResource struct {
+	client *SDKClient
+}
+
+type tokenResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Token        types.String   `tfsdk:"token"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	RotationDays types.Int64    `tfsdk:"rotation_days"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewTokenResource() resource.Resource {
+	return &tokenResource{}
+}
+
+func (r *tokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_personal_access_token"
+}
+
+func (r *tokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	useState := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+
+	resp.Schema = schema.Schema{
+		Description: "A personal access token for the Terminal API. Destroying it revokes the token",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of the token",
+				PlanModifiers: useState,
+			},
+			"token": schema.StringAttribute{
+				Computed:      true,
+				Sensitive:     true,
+				Description:   "The token value. Only known for tokens created by Terraform, imported tokens leave it null",
+				PlanModifiers: useState,
+			},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Timestamp when the token was created",
+				PlanModifiers: useState,
+			},
+			"rotation_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Replace the token with a new one once it is this many days old. The age is checked on each plan",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *tokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+// ModifyPlan replaces the token once it is older than rotation_days. Nothing
+// in the configuration changes, so the computed attributes are marked unknown
+// to carry the replacement.
+func (r *tokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan tokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RotationDays.IsNull() || plan.RotationDays.IsUnknown() {
+		return
+	}
+
+	if !tokenRotationDue(ctx, plan.CreatedAt.ValueString(), plan.RotationDays.ValueInt64(), time.Now()) {
+		return
+	}
+
+	tflog.Info(ctx, "Token is due for rotation", map[string]interface{}{"id": plan.ID.ValueString(), "created_at": plan.CreatedAt.ValueString()})
+
+	plan.ID = types.StringUnknown()
+	plan.Token = types.StringUnknown()
+	plan.CreatedAt = types.StringUnknown()
+	resp.RequiresReplace.Append(path.Root("created_at"))
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// tokenRotationDue reports whether a token created at the given time is at
+// least rotationDays old. Tokens without a readable creation time are kept.
+func tokenRotationDue(ctx context.Context, createdAt string, rotationDays int64, now time.Time) bool {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		tflog.Warn(ctx, "Token has no creation time, skipping rotation", map[string]interface{}{"created_at": createdAt})
+		return false
+	}
+
+	return !now.Before(created.AddDate(0, 0, int(rotationDays)))
+}
+
+func (r *tokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	created, err := r.client.CreateToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating token", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(created.ID)
+	plan.Token = types.StringValue(created.Token)
+	plan.CreatedAt = types.StringNull()
+
+	// Save the token straight away, as its value can't be read again. Only
+	// known values may be saved, so created_at stays null until it is read.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := r.client.GetToken(ctx, created.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading token", err.Error())
+		return
+	}
+
+	plan.CreatedAt = types.StringValue(token.Created)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *tokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	token, err := r.client.GetToken(ctx, state.ID.ValueString())
+	if errors.Is(err, ErrNotFound) {
+		tflog.Warn(ctx, "Token not found, removing from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading token", err.Error())
+		return
+	}
+
+	// The token value is masked after creation, so it is kept from state
+	state.CreatedAt = types.StringValue(token.Created)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *tokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state tokenResourceModel
+
+	// Only rotation_days and the timeouts can change in place, and neither is
+	// sent to the API
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RotationDays = plan.RotationDays
+	state.Timeouts = plan.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *tokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := r.client.DeleteToken(ctx, state.ID.ValueString()); err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Error deleting token", err.Error())
+	}
+}
+
+// ImportState adopts an existing token by ID. Its value can't be read back,
+// so token is left null.
+func (r *tokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
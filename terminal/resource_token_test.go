@@ -0,0 +1,219 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OZCAP/terraform-provider-terminal-coffee/terminal/mockapi"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccToken_basic(t *testing.T) {
+	providerConfig, client := testAccSetup(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTokenDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTokenConfig(providerConfig, 90),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terminal_personal_access_token.test", "id"),
+					resource.TestCheckResourceAttrSet("terminal_personal_access_token.test", "token"),
+					resource.TestCheckResourceAttrSet("terminal_personal_access_token.test", "created_at"),
+					resource.TestCheckResourceAttr("terminal_personal_access_token.test", "rotation_days", "90"),
+				),
+			},
+			{
+				ResourceName:      "terminal_personal_access_token.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The token value can't be read back
+				ImportStateVerifyIgnore: []string{"token", "rotation_days", "timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccToken_rotation(t *testing.T) {
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, mockapi.DefaultToken)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	providerConfig := fmt.Sprintf(`
+provider "terminal" {
+  api_endpoint = %q
+  api_token    = %q
+}
+`, server.URL, mockapi.DefaultToken)
+
+	// The token starts out 45 days old
+	server.API.SetNow(func() time.Time { return time.Now().AddDate(0, 0, -45) })
+
+	var original string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTokenDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTokenConfig(providerConfig, 60),
+				Check:  testAccCheckTokenID("terminal_personal_access_token.test", &original),
+			},
+			{
+				PreConfig: func() { server.API.SetNow(time.Now) },
+				Config:    testAccTokenConfig(providerConfig, 30),
+				Check: func(s *terraform.State) error {
+					var rotated string
+					if err := testAccCheckTokenID("terminal_personal_access_token.test", &rotated)(s); err != nil {
+						return err
+					}
+					if rotated == original {
+						return fmt.Errorf("expected token %s to be rotated", original)
+					}
+					if _, err := client.GetToken(context.Background(), original); !errors.Is(err, ErrNotFound) {
+						return fmt.Errorf("expected token %s to be revoked, got %v", original, err)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestTokenResource_createReadFailure(t *testing.T) {
+	ctx := context.Background()
+
+	// The token is created, but reading it back fails
+	api := mockapi.New()
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/token/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(flaky.Close)
+
+	server, schemas := testConfigureProvider(t, flaky.URL)
+	s := schemas.ResourceSchemas["terminal_personal_access_token"]
+	objectType := s.ValueType()
+
+	priorState, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "terminal_personal_access_token",
+		PriorState:   &priorState,
+		PlannedState: testNullObject(t, objectType, map[string]tftypes.Value{"id": unknown, "token": unknown, "created_at": unknown}),
+		Config:       testNullObject(t, objectType, nil),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Error reading token" {
+		t.Fatalf("Expected only an error reading the token, got %+v", resp.Diagnostics)
+	}
+
+	// The token is kept in state so it isn't orphaned, without any unknown
+	// values, which Terraform can't save
+	state, err := resp.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !state.IsFullyKnown() {
+		t.Errorf("Expected the saved state to be fully known, got %s", state)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if attributes["id"].IsNull() || attributes["token"].IsNull() {
+		t.Errorf("Expected the token's ID and value to be saved, got %s", state)
+	}
+	if !attributes["created_at"].IsNull() {
+		t.Errorf("Expected created_at to be null until it is read, got %s", attributes["created_at"])
+	}
+}
+
+func testAccCheckTokenID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckTokenDestroy(client *SDKClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "terminal_personal_access_token" {
+				continue
+			}
+
+			_, err := client.GetToken(context.Background(), rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("token %s still exists", rs.Primary.ID)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccTokenConfig(providerConfig string, rotationDays int) string {
+	return providerConfig + fmt.Sprintf(`
+resource "terminal_personal_access_token" "test" {
+  rotation_days = %d
+}
+`, rotationDays)
+}
+
+func TestTokenRotationDue(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		createdAt string
+		expected  bool
+	}{
+		{"fresh", "2025-03-30T12:00:00Z", false},
+		{"just under", "2025-03-01T12:00:01Z", false},
+		{"exactly", "2025-03-01T12:00:00Z", true},
+		{"overdue", "2024-12-25T08:00:00Z", true},
+		{"unreadable", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if due := tokenRotationDue(context.Background(), tc.createdAt, 30, now); due != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, due)
+			}
+		})
+	}
+}
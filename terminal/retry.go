@@ -0,0 +1,139 @@
+package terminal
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/terminaldotshop/terminal-sdk-go/option"
+)
+
+// retryPolicy controls how requests that fail with a rate limit or a
+// transient server error are retried
+type retryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// MinWait is the wait before the first retry, doubling for each one after
+	MinWait time.Duration
+	// MaxWait caps the wait between retries. A Retry-After asking for longer
+	// fails the request instead.
+	MaxWait time.Duration
+}
+
+// defaultRetryPolicy applies when the provider block doesn't set one
+var defaultRetryPolicy = retryPolicy{
+	MaxRetries: 3,
+	MinWait:    1 * time.Second,
+	MaxWait:    30 * time.Second,
+}
+
+// retryMiddleware retries requests according to the client's retry policy.
+// Requests that aren't idempotent are never retried, since the API may have
+// acted on them before failing.
+func (c *SDKClient) retryMiddleware(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+	ctx := req.Context()
+	policy := c.Retry
+
+	for attempt := 0; ; attempt++ {
+		res, err := next(req)
+		if attempt >= policy.MaxRetries || !idempotentRequest(req) || !retryableResponse(res, err) || ctx.Err() != nil {
+			return res, err
+		}
+
+		wait, ok := retryWait(res, attempt, policy, time.Now())
+		if !ok {
+			return res, err
+		}
+
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = res.StatusCode
+			res.Body.Close()
+		}
+		tflog.Warn(ctx, "Retrying Terminal API request", fields)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// idempotentRequest reports whether a request can safely be sent again.
+// POSTs, such as placing an order or converting the cart, never qualify: the
+// API may not honour idempotency keys, so resending one could place a second
+// paid order.
+func idempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryableResponse reports whether a request failed in a way that may
+// succeed if tried again
+func retryableResponse(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryWait returns how long to wait before the next attempt: as long as the
+// Retry-After header asks, or an exponential backoff otherwise. It returns
+// false if Retry-After asks for longer than the policy allows.
+func retryWait(res *http.Response, attempt int, policy retryPolicy, now time.Time) (time.Duration, bool) {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), now); ok {
+			return wait, wait <= policy.MaxWait
+		}
+	}
+
+	wait := policy.MinWait << attempt
+	if wait > policy.MaxWait || wait < policy.MinWait {
+		wait = policy.MaxWait
+	}
+
+	return wait, true
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
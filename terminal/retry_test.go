@@ -0,0 +1,136 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client for a server that fails the first
+// failures requests with the given status, and counts every attempt
+func newRetryTestClient(t *testing.T, failures int32, status int, header http.Header) (*SDKClient, *atomic.Int32) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"data": "ord_1"}`))
+			return
+		}
+		w.Write([]byte(`{"data": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.Retry = retryPolicy{MaxRetries: 3, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond}
+
+	return client, &attempts
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	client, attempts := newRetryTestClient(t, 2, http.StatusServiceUnavailable, nil)
+
+	if _, err := client.ListProducts(context.Background()); err != nil {
+		t.Fatalf("Expected the request to succeed after retrying, got %v", err)
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("Expected 3 attempts, got %d", n)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	client, attempts := newRetryTestClient(t, 10, http.StatusTooManyRequests, nil)
+
+	_, err := client.ListProducts(context.Background())
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited once retries ran out, got %v", err)
+	}
+	if n := attempts.Load(); n != 4 {
+		t.Errorf("Expected 4 attempts, got %d", n)
+	}
+}
+
+func TestRetryOrderIdempotency(t *testing.T) {
+	client, attempts := newRetryTestClient(t, 1, http.StatusBadGateway, nil)
+
+	// Without a key the order may have been placed, so it isn't sent again
+	_, err := client.CreateOrder(context.Background(), &Order{Variants: map[string]int{"var_1": 1}})
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("Expected ErrServer, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Expected a single attempt without an idempotency key, got %d", n)
+	}
+
+	// The API may not honour the key, so a keyed order isn't sent again either
+	client, attempts = newRetryTestClient(t, 1, http.StatusBadGateway, nil)
+
+	_, err = client.CreateOrder(context.Background(), &Order{Variants: map[string]int{"var_1": 1}, IdempotencyKey: "offsite"})
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("Expected ErrServer, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Expected a single attempt with an idempotency key, got %d", n)
+	}
+
+	// Converting the cart places an order too
+	client, attempts = newRetryTestClient(t, 1, http.StatusBadGateway, nil)
+
+	if _, err := client.ConvertCart(context.Background()); !errors.Is(err, ErrServer) {
+		t.Fatalf("Expected ErrServer, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Expected a single attempt converting the cart, got %d", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	// Asking for a wait within retry_max_wait is honoured
+	client, attempts := newRetryTestClient(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+	if _, err := client.ListProducts(context.Background()); err != nil {
+		t.Fatalf("Expected the request to succeed after retrying, got %v", err)
+	}
+	if n := attempts.Load(); n != 2 {
+		t.Errorf("Expected 2 attempts, got %d", n)
+	}
+
+	// Asking for longer fails straight away
+	client, attempts = newRetryTestClient(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"60"}})
+	if _, err := client.ListProducts(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Expected a single attempt, got %d", n)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	policy := retryPolicy{MaxRetries: 5, MinWait: time.Second, MaxWait: 5 * time.Second}
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if wait, ok := retryWait(nil, attempt, policy, now); !ok || wait != expected {
+			t.Errorf("Attempt %d: expected %s, got %s (%t)", attempt, expected, wait, ok)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": {now.Add(3 * time.Second).Format(http.TimeFormat)}}}
+	if wait, ok := retryWait(res, 0, policy, now); !ok || wait != 3*time.Second {
+		t.Errorf("Expected the Retry-After date to be honoured, got %s (%t)", wait, ok)
+	}
+}
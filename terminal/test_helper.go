@@ -76,19 +76,60 @@ echo "To test using Terraform directly:"
 echo "1. Build and install the provider:"
 echo "   go build -o terraform-provider-terminal-coffee"
 echo ""
-echo "2. Create a terraform-local.tf file with:"
-echo 'terraform {'
-echo '  required_providers {'
-echo '    terminal-coffee = {'
-echo '      source  = "ozcap/terminal-coffee"'
-echo '    }'
-echo '  }'
-echo '}'
-echo ''
-echo 'provider "terminal-coffee" {'
-echo '  api_token = "your_dev_api_token"'
-echo '  use_dev_environment = true'
-echo '}'
+echo "2. Export the provider settings as Terraform variables:"
+echo "   export TF_VAR_api_token=\$TERMINAL_DEV_API_TOKEN"
+echo "   export TF_VAR_use_dev_environment=true"
+echo ""
+echo "3. Create a terraform-local.tf file with:"
+cat <<'HCL'
+terraform {
+  required_providers {
+    terminal-coffee = {
+      source = "ozcap/terminal-coffee"
+    }
+  }
+}
+
+variable "api_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "use_dev_environment" {
+  type    = bool
+  default = false
+}
+
+provider "terminal-coffee" {
+  api_token           = var.api_token
+  use_dev_environment = var.use_dev_environment
+}
+
+resource "terminal_address" "test" {
+  name    = "Test User"
+  street1 = "123 Test St"
+  city    = "Test City"
+  state   = "CA"
+  zip     = "12345"
+  country = "US"
+}
+
+resource "terminal_payment_card" "test" {
+  token = "tok_visa"
+}
+
+resource "terminal_coffee_order" "test" {
+  address_id = terminal_address.test.id
+  card_id    = terminal_payment_card.test.id
+
+  item {
+    variant_id = "var_9U04ZMMHXK"
+    quantity   = 1
+  }
+}
+HCL
+echo ""
+echo "4. Run terraform init and terraform apply"
 echo ""
 echo "For complete examples, see the examples directory."
 echo ""
@@ -0,0 +1,144 @@
+package terminal
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// validRegexpValidator checks that a string compiles as a regular expression
+type validRegexpValidator struct{}
+
+// validRegexp returns a validator for attributes holding a regular expression
+func validRegexp() validator.String {
+	return validRegexpValidator{}
+}
+
+func (v validRegexpValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v validRegexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validRegexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression", err.Error())
+	}
+}
+
+// validDurationValidator checks that a string parses as a Go duration
+type validDurationValidator struct{}
+
+// validDuration returns a validator for attributes holding a duration such as
+// "30s" or "2m"
+func validDuration() validator.String {
+	return validDurationValidator{}
+}
+
+func (v validDurationValidator) Description(ctx context.Context) string {
+	return "value must be a duration such as \"30s\" or \"2m\""
+}
+
+func (v validDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d < 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", v.Description(ctx))
+	}
+}
+
+// validTimestampValidator checks that a string parses as an RFC 3339
+// timestamp
+type validTimestampValidator struct{}
+
+// validTimestamp returns a validator for attributes holding a timestamp such
+// as "2025-03-01T00:00:00Z"
+func validTimestamp() validator.String {
+	return validTimestampValidator{}
+}
+
+func (v validTimestampValidator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp such as \"2025-03-01T00:00:00Z\""
+}
+
+func (v validTimestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validTimestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timestamp", v.Description(ctx))
+	}
+}
+
+// validPhoneNumberValidator checks that a string is an E.164 phone number
+// once separators are removed
+type validPhoneNumberValidator struct{}
+
+// validPhoneNumber returns a validator for phone number attributes
+func validPhoneNumber() validator.String {
+	return validPhoneNumberValidator{}
+}
+
+func (v validPhoneNumberValidator) Description(ctx context.Context) string {
+	return "value must be an E.164 phone number with a country code, such as \"+14155550123\" or \"+44 20 7946 0958\""
+}
+
+func (v validPhoneNumberValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validPhoneNumberValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !e164Pattern.MatchString(normalizePhoneNumber(req.ConfigValue.ValueString())) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid phone number", v.Description(ctx))
+	}
+}
+
+// validCountryCodeValidator checks that a string is an ISO 3166-1 alpha-2
+// country code, ignoring case and surrounding whitespace
+type validCountryCodeValidator struct{}
+
+// validCountryCode returns a validator for country code attributes
+func validCountryCode() validator.String {
+	return validCountryCodeValidator{}
+}
+
+func (v validCountryCodeValidator) Description(ctx context.Context) string {
+	return "value must be an ISO 3166-1 alpha-2 country code such as \"US\" or \"GB\""
+}
+
+func (v validCountryCodeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validCountryCodeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !isoCountryCodes[normalizeAddressCode(req.ConfigValue.ValueString())] {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid country code", v.Description(ctx))
+	}
+}